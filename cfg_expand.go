@@ -0,0 +1,157 @@
+package flag
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// expands the envs in the values of the cfg files loaded to the default flagset
+// https://github.com/ondbyte/turbo_flag#env-interpolation
+func EnableEnvExpansion() {
+	CommandLine.EnableEnvExpansion()
+}
+
+// expands the envs in the string values of the cfg files loaded after it, before they are bound to the flags.
+// ${VAR} is the value of VAR, ${VAR:-default} is default when VAR is not set or empty, ${VAR:?message}
+// fails the loading with message when VAR is not set or empty and $$ is a literal $. $VAR is left as it is.
+// https://github.com/ondbyte/turbo_flag#env-interpolation
+func (fs *FlagSet) EnableEnvExpansion() {
+	if fs.parentCmd != nil {
+		fs.parentCmd.EnableEnvExpansion()
+		return
+	}
+	fs.expandEnv = true
+}
+
+// expandEnvValues expands the envs in the strings of v, notation is the dot notation of v for the errors
+func expandEnvValues(v interface{}, notation string) (interface{}, error) {
+	if m, err := stringMap(v); err == nil {
+		for key, value := range m {
+			expanded, err := expandEnvValues(value, joinNotation(notation, key))
+			if err != nil {
+				return nil, err
+			}
+			m[key] = expanded
+		}
+		return m, nil
+	}
+	switch v := v.(type) {
+	case string:
+		expanded, err := expandEnv(v)
+		if err != nil {
+			return nil, fmt.Errorf("unable to expand the value of %v : %v", notation, err)
+		}
+		return expanded, nil
+	case []interface{}:
+		for i, value := range v {
+			expanded, err := expandEnvValues(value, joinNotation(notation, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			v[i] = expanded
+		}
+		return v, nil
+	case []map[string]interface{}:
+		// the arrays of tables of the formats which don't decode them to []interface{}
+		for i, value := range v {
+			if _, err := expandEnvValues(value, joinNotation(notation, strconv.Itoa(i))); err != nil {
+				return nil, err
+			}
+		}
+		return v, nil
+	}
+	return v, nil
+}
+
+func joinNotation(prefix string, key string) string {
+	if prefix == "" {
+		return notationKey(key)
+	}
+	return prefix + "." + notationKey(key)
+}
+
+// expandEnv expands ${VAR}, ${VAR:-default} and ${VAR:?message} in s, $$ is a literal $
+func expandEnv(s string) (string, error) {
+	if !strings.Contains(s, "$") {
+		return s, nil
+	}
+	b := new(strings.Builder)
+	for i := 0; i < len(s); i++ {
+		if s[i] != '$' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		switch s[i+1] {
+		case '$':
+			b.WriteByte('$')
+			i++
+			continue
+		case '{':
+		default:
+			b.WriteByte('$')
+			continue
+		}
+		end := closingBrace(s, i+2)
+		if end < 0 {
+			return "", fmt.Errorf("missing } in %q", s[i:])
+		}
+		value, err := expandEnvExpr(s[i+2 : end])
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(value)
+		i = end
+	}
+	return b.String(), nil
+}
+
+// closingBrace returns the index of the } closing the ${ before start, -1 if it is not closed
+func closingBrace(s string, start int) int {
+	depth := 0
+	for i := start; i < len(s); i++ {
+		switch {
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '$':
+			i++
+		case s[i] == '$' && i+1 < len(s) && s[i+1] == '{':
+			depth++
+			i++
+		case s[i] == '}' && depth == 0:
+			return i
+		case s[i] == '}':
+			depth--
+		}
+	}
+	return -1
+}
+
+// expandEnvExpr expands the expression inside ${}
+func expandEnvExpr(expr string) (string, error) {
+	name, op, arg := expr, "", ""
+	if i := strings.Index(expr, ":"); i >= 0 && i+1 < len(expr) && (expr[i+1] == '-' || expr[i+1] == '?') {
+		name, op, arg = expr[:i], expr[i:i+2], expr[i+2:]
+	}
+	if name == "" {
+		return "", fmt.Errorf("empty env name in ${%v}", expr)
+	}
+	value := os.Getenv(name)
+	if value != "" {
+		return value, nil
+	}
+	switch op {
+	case ":-":
+		// the default can have envs too
+		return expandEnv(arg)
+	case ":?":
+		message, err := expandEnv(arg)
+		if err != nil {
+			return "", err
+		}
+		if message == "" {
+			message = "not set"
+		}
+		return "", fmt.Errorf("%v: %v", name, message)
+	}
+	return "", nil
+}
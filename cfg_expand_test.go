@@ -0,0 +1,79 @@
+package flag_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_EnableEnvExpansion(t *testing.T) {
+	t.Setenv("TEST_DB_PASSWORD", "secret")
+	t.Setenv("TEST_PORT", "5432")
+	t.Setenv("TEST_EMPTY", "")
+	content := []byte(`
+password: ${TEST_DB_PASSWORD}
+url: ${TEST_HOST:-localhost}:${TEST_PORT}
+fallback: ${TEST_EMPTY:-${TEST_PORT}}
+literal: $${TEST_PORT} costs $5
+hosts: ["${TEST_HOST:-a}", "b"]
+`)
+	fs := NewFlagSet("tool", ContinueOnError)
+	password := fs.String("password", "", "", fs.Cfg("password"))
+	url := fs.String("url", "", "", fs.Cfg("url"))
+	fallback := fs.String("fallback", "", "", fs.Cfg("fallback"))
+	literal := fs.String("literal", "", "", fs.Cfg("literal"))
+	hosts := fs.String("hosts", "", "", fs.Cfg("hosts"))
+
+	err := fs.LoadCfgBytes(content, "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *password != "${TEST_DB_PASSWORD}" {
+		t.Errorf("expected the envs to not be expanded unless it is enabled, got %v", *password)
+	}
+
+	fs.EnableEnvExpansion()
+	err = fs.LoadCfgBytes(content, "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"password": "secret",
+		"url":      "localhost:5432",
+		"fallback": "5432",
+		"literal":  "${TEST_PORT} costs $5",
+		"hosts":    `["a","b"]`,
+	}
+	got := map[string]string{
+		"password": *password,
+		"url":      *url,
+		"fallback": *fallback,
+		"literal":  *literal,
+		"hosts":    *hosts,
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("expected %v to be %q, got %q", key, value, got[key])
+		}
+	}
+
+	err = fs.LoadCfgBytes([]byte(`{"database":{"password":"${TEST_MISSING:?set the database password}"}}`), "json")
+	if err == nil || !strings.Contains(err.Error(), "database.password") || !strings.Contains(err.Error(), "TEST_MISSING: set the database password") {
+		t.Errorf("expected the error of the missing env, got %v", err)
+	}
+	err = fs.LoadCfgBytes([]byte(`password = "${TEST_DB_PASSWORD"`), "toml")
+	if err == nil {
+		t.Error("expected error for the unclosed ${")
+	}
+
+	// the arrays of tables of TOML are expanded like the other arrays
+	host := fs.String("host", "", "", fs.Cfg("servers.1.host"))
+	err = fs.LoadCfgBytes([]byte("[[servers]]\nhost = \"a\"\n[[servers]]\nhost = \"${TEST_HOST:-db}:${TEST_PORT}\"\n"), "toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *host != "db:5432" {
+		t.Errorf("expected the env in the array of tables to be expanded, got %q", *host)
+	}
+}
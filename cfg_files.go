@@ -5,10 +5,14 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
 	"github.com/BurntSushi/toml"
 	"github.com/go-yaml/yaml"
+	"github.com/hashicorp/hcl"
 )
 
 // EnvToMap parses an environment file content and returns the key-value pairs as a map.
@@ -99,7 +103,24 @@ func YAMLToMap(content string) (map[string]interface{}, error) {
 	if err != nil {
 		return m, fmt.Errorf("unable create map from YAML : %v", err)
 	}
-	return m, nil
+	return yamlStringMaps(m).(map[string]interface{}), nil
+}
+
+// yamlStringMaps turns the maps YAML decodes to map[interface{}]interface{} into string keyed maps,
+// including the ones in arrays
+func yamlStringMaps(v interface{}) interface{} {
+	if m, err := stringMap(v); err == nil {
+		for key, value := range m {
+			m[key] = yamlStringMaps(value)
+		}
+		return m
+	}
+	if array, ok := v.([]interface{}); ok {
+		for i, value := range array {
+			array[i] = yamlStringMaps(value)
+		}
+	}
+	return v
 }
 
 func TOMLToMap(content string) (map[string]interface{}, error) {
@@ -108,41 +129,325 @@ func TOMLToMap(content string) (map[string]interface{}, error) {
 	if err != nil {
 		return nil, err
 	}
-	return config, nil
+	return tomlTables(config).(map[string]interface{}), nil
+}
+
+// tomlTables turns the arrays of tables TOML decodes to []map[string]interface{} into []interface{}
+// like the other arrays, so the dot notation can index them
+func tomlTables(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = tomlTables(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = tomlTables(value)
+		}
+	case []map[string]interface{}:
+		array := make([]interface{}, len(v))
+		for i, value := range v {
+			array[i] = tomlTables(value)
+		}
+		return array
+	}
+	return v
 }
 
-/* // ReadProperties reads the contents of a Property file from a string and returns a map[string]string
-func ReadProperties(content string) (map[string]interface{}, error) {
+// INIToMap reads the contents of an INI file from a string and returns a map[string]interface{},
+// the keys of a section like [database.primary] are put under the dot notation database.primary
+func INIToMap(content string) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
-	lines := strings.Split(content, "\n")
-	for _, line := range lines {
+	section := ""
+	for i, line := range strings.Split(content, "\n") {
 		line = strings.TrimSpace(line)
-		if len(line) == 0 || line[0] == '#' {
+		if line == "" || line[0] == ';' || line[0] == '#' {
 			continue
-	result2 := make(map[string]interface{})
-	for k, v := range result {
-		result2[k] = v
+		}
+		if line[0] == '[' {
+			if line[len(line)-1] != ']' {
+				return nil, fmt.Errorf("invalid section at line %v : %v", i+1, line)
+			}
+			section = strings.TrimSpace(line[1 : len(line)-1])
+			if section == "" {
+				return nil, fmt.Errorf("empty section at line %v", i+1)
+			}
+			// so the empty sections are kept
+			if len(lookupNotation(result, literalSegments(section))) == 0 {
+				err := setLiteral(result, section, map[string]interface{}{})
+				if err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+		sep := strings.IndexAny(line, "=:")
+		if sep <= 0 {
+			return nil, fmt.Errorf("invalid line %v : %v", i+1, line)
+		}
+		key := strings.TrimSpace(line[:sep])
+		if section != "" {
+			key = section + "." + key
+		}
+		err := setLiteral(result, key, iniValue(strings.TrimSpace(line[sep+1:])))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key at line %v : %v", i+1, err)
+		}
+	}
+	return result, nil
+}
+
+// setLiteral sets value in data at key split on the dots, see literalSegments. a key can't have
+// both a value and keys under it, like log=INFO and log.file=x
+func setLiteral(data map[string]interface{}, key string, value interface{}) error {
+	keys := strings.Split(key, ".")
+	m := data
+	for i, k := range keys[:len(keys)-1] {
+		next, ok := m[k]
+		if !ok {
+			next = make(map[string]interface{})
+			m[k] = next
+		}
+		nextMap, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("key %v is under %v which has the value %v", key, strings.Join(keys[:i+1], "."), next)
+		}
+		m = nextMap
 	}
+	last := keys[len(keys)-1]
+	if _, ok := m[last].(map[string]interface{}); ok {
+		return fmt.Errorf("key %v has keys under it, it can't have a value", key)
+	}
+	m[last] = value
+	return nil
+}
+
+// iniValue unquotes a quoted value or removes the inline comment of an unquoted one
+func iniValue(value string) string {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		if unquoted, err := strconv.Unquote(value); err == nil {
+			return unquoted
 		}
-		parts :2= strings.Split(line, "#")
-		keyValue := strings.TrimSpace(parts[0])
-		if len(keyValue) == 0 {
+	}
+	for _, comment := range []string{" ;", " #", "\t;", "\t#"} {
+		if i := strings.Index(value, comment); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+	}
+	return value
+}
+
+// MapToINI writes a map to an INI string, the nested maps are written as sections like [database.primary]
+func MapToINI(data map[string]interface{}) (string, error) {
+	m, err := stringMap(data)
+	if err != nil {
+		return "", fmt.Errorf("unable to map to INI : %v", err)
+	}
+	b := new(strings.Builder)
+	err = writeINISection(b, "", m)
+	if err != nil {
+		return "", fmt.Errorf("unable to map to INI : %v", err)
+	}
+	return b.String(), nil
+}
+
+func writeINISection(b *strings.Builder, section string, m map[string]interface{}) error {
+	var sections []string
+	values := make(map[string]string)
+	for key, value := range m {
+		if _, err := stringMap(value); err == nil {
+			sections = append(sections, key)
 			continue
 		}
-		parts = strings.SplitN(keyValue, "=", 2)
-		if len(parts) == 2 {
-			key := strings.TrimSpace(parts[0])
-			value := strings.TrimSpace(parts[1])
-			result[key] = value
+		text, err := cfgText(value)
+		if err != nil {
+			return err
+		}
+		if text != strings.TrimSpace(text) || strings.ContainsAny(text, ";#\"\n") {
+			text = strconv.Quote(text)
+		}
+		values[key] = text
+	}
+	if section != "" && (len(values) > 0 || len(sections) == 0) {
+		if b.Len() > 0 {
+			b.WriteString("\n")
 		}
+		fmt.Fprintf(b, "[%v]\n", section)
 	}
+	for _, key := range sortedStringKeys(values) {
+		fmt.Fprintf(b, "%v = %v\n", key, values[key])
+	}
+	sort.Strings(sections)
+	for _, key := range sections {
+		sub, _ := stringMap(m[key])
+		if section != "" {
+			key = section + "." + key
+		}
+		err := writeINISection(b, key, sub)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// PropertiesToMap reads the contents of a Java .properties file from a string and returns a map[string]interface{},
+// the dotted keys like database.password are put under their dot notation
+func PropertiesToMap(content string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimLeft(lines[i], " \t\f")
+		if line == "" || line[0] == '#' || line[0] == '!' {
+			continue
+		}
+		// a line ending with an odd number of backslashes continues on the next line
+		for endsWithEscape(line) && i+1 < len(lines) {
+			i++
+			line = line[:len(line)-1] + strings.TrimLeft(lines[i], " \t\f")
+		}
+		key, value := splitProperty(line)
+		err := setLiteral(result, unescapeProperty(key), unescapeProperty(value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid key at line %v : %v", i+1, err)
+		}
+	}
 	return result, nil
-} */
+}
 
-func jsonnify(v interface{}) (string, error) {
-	_, ok := v.(map[string]interface{})
-	if ok {
+func endsWithEscape(line string) bool {
+	backslashes := 0
+	for i := len(line) - 1; i >= 0 && line[i] == '\\'; i-- {
+		backslashes++
+	}
+	return backslashes%2 == 1
+}
+
+// splitProperty splits the line at the first unescaped '=', ':' or white space
+func splitProperty(line string) (key string, value string) {
+	end := len(line)
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' {
+			i++
+			continue
+		}
+		if strings.IndexByte("=: \t\f", line[i]) >= 0 {
+			end = i
+			break
+		}
+	}
+	key, value = line[:end], strings.TrimLeft(line[end:], " \t\f")
+	if value != "" && (value[0] == '=' || value[0] == ':') {
+		value = strings.TrimLeft(value[1:], " \t\f")
+	}
+	return key, value
+}
+
+func unescapeProperty(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	b := new(strings.Builder)
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch s[i] {
+		case 't':
+			b.WriteByte('\t')
+		case 'n':
+			b.WriteByte('\n')
+		case 'r':
+			b.WriteByte('\r')
+		case 'f':
+			b.WriteByte('\f')
+		case 'u':
+			if i+4 < len(s) {
+				if r, err := strconv.ParseUint(s[i+1:i+5], 16, 16); err == nil {
+					b.WriteRune(rune(r))
+					i += 4
+					continue
+				}
+			}
+			b.WriteByte('u')
+		default:
+			b.WriteByte(s[i])
+		}
+	}
+	return b.String()
+}
+
+// MapToProperties writes a map to a Java .properties string, the nested maps are written as dotted keys
+func MapToProperties(data map[string]interface{}) (string, error) {
+	m, err := stringMap(data)
+	if err != nil {
+		return "", fmt.Errorf("unable to map to properties : %v", err)
+	}
+	properties := make(map[string]string)
+	err = flattenCfg(m, "", properties)
+	if err != nil {
+		return "", fmt.Errorf("unable to map to properties : %v", err)
+	}
+	b := new(strings.Builder)
+	for _, key := range sortedStringKeys(properties) {
+		fmt.Fprintf(b, "%v=%v\n", escapeProperty(key, true), escapeProperty(properties[key], false))
+	}
+	return b.String(), nil
+}
+
+// flattenCfg puts the values of m in flat keyed by their dot notation
+func flattenCfg(m map[string]interface{}, prefix string, flat map[string]string) error {
+	for key, value := range m {
+		if sub, err := stringMap(value); err == nil {
+			err = flattenCfg(sub, prefix+key+".", flat)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		text, err := cfgText(value)
+		if err != nil {
+			return err
+		}
+		flat[prefix+key] = text
+	}
+	return nil
+}
+
+func escapeProperty(s string, isKey bool) string {
+	b := new(strings.Builder)
+	for i, r := range s {
+		switch r {
+		case '\\':
+			b.WriteString(`\\`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '=', ':', '#', '!':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case ' ':
+			if isKey || i == 0 {
+				b.WriteByte('\\')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// cfgText returns the text a value is written as in the formats without types, the arrays are written as JSON
+func cfgText(v interface{}) (string, error) {
+	if _, ok := v.([]interface{}); ok {
 		b, err := json.Marshal(v)
 		if err != nil {
 			return "", err
@@ -152,31 +457,159 @@ func jsonnify(v interface{}) (string, error) {
 	return fmt.Sprintf("%v", v), nil
 }
 
-func getValueByNotationArray(inputMap map[string]interface{}, notation []string) (s string, err error) {
-	data, err := stringMap(inputMap)
+func sortedStringKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HCLToMap reads the contents of a HCL file from a string and returns a map[string]interface{},
+// the blocks become maps so a block like database { password = "x" } binds to database.password,
+// the labels of a block like service "web" { port = 80 } are nested like service.web.port
+// and the repeated blocks are merged.
+func HCLToMap(content string) (map[string]interface{}, error) {
+	var config map[string]interface{}
+	err := hcl.Decode(&config, content)
+	if err != nil {
+		return nil, err
+	}
+	return hclNormalize(config).(map[string]interface{}), nil
+}
+
+// hclNormalize turns the lists of maps HCL decodes the blocks to into maps
+func hclNormalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = hclNormalize(value)
+		}
+		return v
+	case []map[string]interface{}:
+		merged := make(map[string]interface{})
+		for _, block := range v {
+			deepMerge(merged, hclNormalize(block).(map[string]interface{}), ArrayAppend, "", make(map[string]string), "")
+		}
+		return merged
+	case []interface{}:
+		for i, value := range v {
+			v[i] = hclNormalize(value)
+		}
+		return v
+	}
+	return v
+}
+
+// MapToHCL writes a map to a HCL string, the nested maps are written as blocks
+func MapToHCL(data map[string]interface{}) (string, error) {
+	m, err := stringMap(data)
+	if err != nil {
+		return "", fmt.Errorf("unable to map to HCL : %v", err)
+	}
+	b := new(strings.Builder)
+	err = writeHCLBody(b, m, "")
 	if err != nil {
-		return "", fmt.Errorf("unable to get value by dot notation : %v", err)
+		return "", fmt.Errorf("unable to map to HCL : %v", err)
 	}
-	key := notation[0]
-	notation = notation[1:]
-	nextData := data[key]
-	if len(notation) == 0 {
-		return jsonnify(nextData)
+	return b.String(), nil
+}
+
+func writeHCLBody(b *strings.Builder, m map[string]interface{}, indent string) error {
+	var blocks, attributes []string
+	for key, value := range m {
+		if _, err := stringMap(value); err == nil {
+			blocks = append(blocks, key)
+		} else if value != nil {
+			attributes = append(attributes, key)
+		}
+	}
+	sort.Strings(attributes)
+	for _, key := range attributes {
+		value, err := hclValue(m[key], indent)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "%v%v = %v\n", indent, hclKey(key), value)
 	}
-	if nextMap, ok := nextData.(map[string]interface{}); ok {
-		//value is a map, go recursive
-		v, err := getValueByNotationArray(nextMap, notation)
+	sort.Strings(blocks)
+	for i, key := range blocks {
+		if i > 0 || len(attributes) > 0 {
+			b.WriteString("\n")
+		}
+		block, _ := stringMap(m[key])
+		fmt.Fprintf(b, "%v%v {\n", indent, hclKey(key))
+		err := writeHCLBody(b, block, indent+"  ")
 		if err != nil {
-			return "", fmt.Errorf("unable to get value by dot notation : %v", err)
+			return err
 		}
-		return v, nil
+		fmt.Fprintf(b, "%v}\n", indent)
+	}
+	return nil
+}
+
+func hclValue(v interface{}, indent string) (string, error) {
+	if m, err := stringMap(v); err == nil {
+		b := new(strings.Builder)
+		b.WriteString("{\n")
+		err = writeHCLBody(b, m, indent+"  ")
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(indent + "}")
+		return b.String(), nil
+	}
+	switch v := v.(type) {
+	case string:
+		return strconv.Quote(v), nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprintf("%v", v), nil
+	case []interface{}:
+		values := make([]string, len(v))
+		for i, value := range v {
+			text, err := hclValue(value, indent)
+			if err != nil {
+				return "", err
+			}
+			values[i] = text
+		}
+		return "[" + strings.Join(values, ", ") + "]", nil
+	}
+	return "", fmt.Errorf("unsupported value %v of type %T", v, v)
+}
+
+// hclKey quotes the key unless it is an identifier
+func hclKey(key string) string {
+	for i, r := range key {
+		if !(r == '_' || unicode.IsLetter(r) || (i > 0 && (r == '-' || r == '.' || unicode.IsDigit(r)))) {
+			return strconv.Quote(key)
+		}
+	}
+	if key == "" {
+		return `""`
 	}
-	return "", fmt.Errorf("value not found for dot notation")
+	return key
+}
+
+func jsonnify(v interface{}) (string, error) {
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	}
+	return fmt.Sprintf("%v", v), nil
 }
 
 func getValueByDotNotation(inputMap map[string]interface{}, not string) (s string, err error) {
-	sNotation := strings.Split(not, ".")
-	return getValueByNotationArray(inputMap, sNotation)
+	values, err := getValuesByDotNotation(inputMap, not)
+	if err != nil {
+		return "", err
+	}
+	return values[0], nil
 }
 
 func stringMap(inputMap interface{}) (map[string]interface{}, error) {
@@ -232,37 +665,16 @@ func stringMap(inputMap interface{}) (map[string]interface{}, error) {
 
 func setValueByDotNotation(data map[string]interface{}, notation string, value interface{}) (map[string]interface{}, error) {
 	current, err := stringMap(data)
-	lastData := current
 	if err != nil {
-		return lastData, err
+		return current, err
 	}
-	keys := strings.Split(notation, ".")
-
-	for {
-		key := keys[0]
-		keys = keys[1:]
-
-		lastKey := len(keys) == 0
-		if lastKey {
-			current[key] = value
-			break
-		} else {
-			m, ok := current[key]
-			if ok {
-				mm, ok := m.(map[string]interface{})
-				if ok {
-					current = mm
-				} else {
-					v := map[string]interface{}{}
-					current[key] = v
-					current = v
-				}
-			} else {
-				v := map[string]interface{}{}
-				current[key] = v
-				current = v
-			}
-		}
+	segments, err := parseNotation(notation)
+	if err != nil {
+		return current, err
+	}
+	result, err := setNotation(current, segments, value)
+	if err != nil {
+		return current, err
 	}
-	return lastData, nil
+	return result.(map[string]interface{}), nil
 }
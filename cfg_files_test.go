@@ -59,6 +59,14 @@ func Test_jsonnify(t *testing.T) {
 			want:    `{"yadu":true}`,
 			wantErr: false,
 		},
+		{
+			name: "array",
+			args: args{
+				v: []interface{}{"a", map[string]interface{}{"b": 1}},
+			},
+			want:    `["a",{"b":1}]`,
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -374,3 +382,364 @@ func TestAddValueByDotNotation(t *testing.T) {
 	}
 
 }
+
+func TestINIToMap(t *testing.T) {
+	content := `; comment line
+name = tool
+# another comment line
+[server]
+address = localhost ; inline comment
+port: 8080
+
+[database.primary]
+password = "secret ; not a comment"
+
+[empty]
+
+[a[x]]
+key.* = 1
+servers.0 = a
+`
+	expected := map[string]interface{}{
+		"name": "tool",
+		"server": map[string]interface{}{
+			"address": "localhost",
+			"port":    "8080",
+		},
+		"a[x]": map[string]interface{}{
+			"key":     map[string]interface{}{"*": "1"},
+			"servers": map[string]interface{}{"0": "a"},
+		},
+		"database": map[string]interface{}{
+			"primary": map[string]interface{}{
+				"password": "secret ; not a comment",
+			},
+		},
+		"empty": map[string]interface{}{},
+	}
+	result, err := INIToMap(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Result does not match expected value.\nExpected: %v\nGot: %v", expected, result)
+	}
+	_, err = INIToMap("[server\nport = 1")
+	if err == nil {
+		t.Error("expected error for an invalid section")
+	}
+	for _, content := range []string{"log = INFO\n[log]\nfile = x", "log.file = x\nlog = INFO"} {
+		_, err = INIToMap(content)
+		if err == nil {
+			t.Errorf("expected error for a key with both a value and keys under it in %q", content)
+		}
+	}
+}
+
+func TestMapToINI(t *testing.T) {
+	data := map[string]interface{}{
+		"name": "tool",
+		"server": map[interface{}]interface{}{
+			"address": "localhost",
+			"port":    8080,
+		},
+		"database": map[string]interface{}{
+			"primary": map[string]interface{}{
+				"password": " secret",
+				"hosts":    []interface{}{"a", "b"},
+			},
+		},
+	}
+	expectedINI := `name = tool
+
+[database.primary]
+hosts = "[\"a\",\"b\"]"
+password = " secret"
+
+[server]
+address = localhost
+port = 8080
+`
+	iniContent, err := MapToINI(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if iniContent != expectedINI {
+		t.Errorf("Unexpected INI content. Expected:\n%s\nGot:\n%s", expectedINI, iniContent)
+	}
+	result, err := INIToMap(iniContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := getValueByDotNotation(result, "database.primary.password")
+	if err != nil || v != " secret" {
+		t.Errorf("expected the written INI to read back, got %q : %v", v, err)
+	}
+}
+
+func TestPropertiesToMap(t *testing.T) {
+	content := `# comment line
+! another comment line
+name=tool
+server.address : localhost
+server.port 8080
+database.password = p\=ss\u0021
+message = hello \
+          world
+path\ with\ spaces=c:\\temp
+a[x]=1
+key.*=1
+`
+	expected := map[string]interface{}{
+		"a[x]": "1",
+		"key":  map[string]interface{}{"*": "1"},
+		"name": "tool",
+		"server": map[string]interface{}{
+			"address": "localhost",
+			"port":    "8080",
+		},
+		"database": map[string]interface{}{
+			"password": "p=ss!",
+		},
+		"message":          "hello world",
+		"path with spaces": `c:\temp`,
+	}
+	result, err := PropertiesToMap(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Result does not match expected value.\nExpected: %v\nGot: %v", expected, result)
+	}
+	for _, content := range []string{"log=INFO\nlog.file=/var/log/x", "log.file=/var/log/x\nlog=INFO"} {
+		_, err = PropertiesToMap(content)
+		if err == nil {
+			t.Errorf("expected error for a key with both a value and keys under it in %q", content)
+		}
+	}
+}
+
+func TestMapToProperties(t *testing.T) {
+	data := map[string]interface{}{
+		"name": "tool",
+		"server": map[interface{}]interface{}{
+			"address": "localhost",
+			"port":    8080,
+		},
+		"database": map[string]interface{}{
+			"password": "p=ss\n",
+		},
+		"path with spaces": `c:\temp`,
+	}
+	expectedProperties := `database.password=p\=ss\n
+name=tool
+path\ with\ spaces=c\:\\temp
+server.address=localhost
+server.port=8080
+`
+	propertiesContent, err := MapToProperties(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if propertiesContent != expectedProperties {
+		t.Errorf("Unexpected properties content. Expected:\n%s\nGot:\n%s", expectedProperties, propertiesContent)
+	}
+	result, err := PropertiesToMap(propertiesContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := getValueByDotNotation(result, "database.password")
+	if err != nil || v != "p=ss\n" {
+		t.Errorf("expected the written properties to read back, got %q : %v", v, err)
+	}
+}
+
+func TestHCLToMap(t *testing.T) {
+	content := `# comment line
+name = "tool"
+ports = [80, 443]
+database {
+  password = "secret"
+}
+service "web" {
+  port = 80
+}
+service "db" {
+  port = 5432
+}
+`
+	expected := map[string]interface{}{
+		"name":  "tool",
+		"ports": []interface{}{80, 443},
+		"database": map[string]interface{}{
+			"password": "secret",
+		},
+		"service": map[string]interface{}{
+			"web": map[string]interface{}{"port": 80},
+			"db":  map[string]interface{}{"port": 5432},
+		},
+	}
+	result, err := HCLToMap(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Errorf("Result does not match expected value.\nExpected: %v\nGot: %v", expected, result)
+	}
+	v, err := getValueByDotNotation(result, "service.db.port")
+	if err != nil || v != "5432" {
+		t.Errorf("expected the labels of the block to be nested, got %q : %v", v, err)
+	}
+}
+
+func TestMapToHCL(t *testing.T) {
+	data := map[string]interface{}{
+		"name":  "tool",
+		"ports": []interface{}{80, 443},
+		"server": map[interface{}]interface{}{
+			"address": "localhost",
+			"enabled": true,
+		},
+		"database": map[string]interface{}{
+			"primary": map[string]interface{}{
+				"password": "p\"ss",
+			},
+		},
+		"my key": 1.5,
+	}
+	expectedHCL := `"my key" = 1.5
+name = "tool"
+ports = [80, 443]
+
+database {
+  primary {
+    password = "p\"ss"
+  }
+}
+
+server {
+  address = "localhost"
+  enabled = true
+}
+`
+	hclContent, err := MapToHCL(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hclContent != expectedHCL {
+		t.Errorf("Unexpected HCL content. Expected:\n%s\nGot:\n%s", expectedHCL, hclContent)
+	}
+	result, err := HCLToMap(hclContent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, err := getValueByDotNotation(result, "database.primary.password")
+	if err != nil || v != "p\"ss" {
+		t.Errorf("expected the written HCL to read back, got %q : %v", v, err)
+	}
+}
+
+func TestGetValuesByDotNotation(t *testing.T) {
+	data, err := YAMLToMap(`
+servers:
+  - host: a
+    port: 1
+  - host: b
+    port: 2
+example.com:
+  port: 443
+ports:
+  http: 80
+  https: 443
+"0": zero
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		notation string
+		expected []string
+	}{
+		{"servers.0.host", []string{"a"}},
+		{"servers[1].host", []string{"b"}},
+		{"servers[1]", []string{`{"host":"b","port":2}`}},
+		{`"example.com".port`, []string{"443"}},
+		{`['example.com'].port`, []string{"443"}},
+		{`ports["https"]`, []string{"443"}},
+		{"servers.*.host", []string{"a", "b"}},
+		{"servers[*].port", []string{"1", "2"}},
+		{"ports.*", []string{"80", "443"}},
+		{"0", []string{"zero"}},
+	}
+	for _, test := range tests {
+		values, err := getValuesByDotNotation(data, test.notation)
+		if err != nil || !reflect.DeepEqual(values, test.expected) {
+			t.Errorf("Unexpected values for notation %s. Expected: %v, Got: %v : %v", test.notation, test.expected, values, err)
+		}
+	}
+	for _, notation := range []string{"servers.2.host", "example.com.port", "servers[0", `"example.com`, "servers[host]", "servers.*.missing"} {
+		values, err := getValuesByDotNotation(data, notation)
+		if err == nil {
+			t.Errorf("expected error for notation %s, got %v", notation, values)
+		}
+	}
+
+	// the arrays of tables of TOML are indexed like the arrays of YAML
+	data, err = TOMLToMap(`
+[[servers]]
+host = "a"
+[[servers]]
+host = "b"
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for notation, expected := range map[string][]string{
+		"servers.0.host":  {"a"},
+		"servers[1].host": {"b"},
+		"servers.*.host":  {"a", "b"},
+		"servers[*].host": {"a", "b"},
+	} {
+		values, err := getValuesByDotNotation(data, notation)
+		if err != nil || !reflect.DeepEqual(values, expected) {
+			t.Errorf("Unexpected values for TOML notation %s. Expected: %v, Got: %v : %v", notation, expected, values, err)
+		}
+	}
+}
+
+func TestSetValueByDotNotationIndexes(t *testing.T) {
+	data := map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a"},
+		},
+	}
+	var err error
+	for notation, value := range map[string]interface{}{
+		"servers.0.port":     1,
+		"servers[1].host":    "b",
+		`"example.com".port`: 443,
+		"list[1]":            "second",
+		`ports["https"]`:     443,
+	} {
+		data, err = setValueByDotNotation(data, notation, value)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected := map[string]interface{}{
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a", "port": 1},
+			map[string]interface{}{"host": "b"},
+		},
+		"example.com": map[string]interface{}{"port": 443},
+		"list":        []interface{}{nil, "second"},
+		"ports":       map[string]interface{}{"https": 443},
+	}
+	if !reflect.DeepEqual(data, expected) {
+		t.Errorf("Result does not match expected value.\nExpected: %v\nGot: %v", expected, data)
+	}
+	_, err = setValueByDotNotation(data, "servers.*.host", "c")
+	if err == nil {
+		t.Error("expected error while setting a wildcard")
+	}
+}
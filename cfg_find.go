@@ -0,0 +1,100 @@
+package flag
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// cfgSearchDirs returns the directories to look for the cfg files in, the most specific one first:
+// dirs, the working directory, $XDG_CONFIG_HOME (~/.config), $HOME and $XDG_CONFIG_DIRS (/etc/xdg)
+func cfgSearchDirs(dirs ...string) []string {
+	searchDirs := append([]string{}, dirs...)
+	if wd, err := os.Getwd(); err == nil {
+		searchDirs = append(searchDirs, wd)
+	}
+	home, _ := os.UserHomeDir()
+	if configHome := os.Getenv("XDG_CONFIG_HOME"); configHome != "" {
+		searchDirs = append(searchDirs, configHome)
+	} else if home != "" {
+		searchDirs = append(searchDirs, filepath.Join(home, ".config"))
+	}
+	if home != "" {
+		searchDirs = append(searchDirs, home)
+	}
+	configDirs := os.Getenv("XDG_CONFIG_DIRS")
+	if configDirs == "" {
+		configDirs = "/etc/xdg"
+	}
+	for _, dir := range filepath.SplitList(configDirs) {
+		if dir != "" {
+			searchDirs = append(searchDirs, dir)
+		}
+	}
+	return searchDirs
+}
+
+// findCfgFiles returns the cfg files named name with the extension of a registered format found in the search
+// directories, the most specific one first, along with the patterns searched
+func (fs *FlagSet) findCfgFiles(name string, dirs ...string) (found []string, searched []string) {
+	cfgExtensions := fs.cfgExtensions()
+	seen := make(map[string]bool)
+	for _, dir := range cfgSearchDirs(dirs...) {
+		dir = filepath.Clean(dir)
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		searched = append(searched, filepath.Join(dir, name+".{"+strings.Join(cfgExtensions, ",")+"}"))
+		for _, ext := range cfgExtensions {
+			path := filepath.Join(dir, name+"."+ext)
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				found = append(found, path)
+				// one file per directory
+				break
+			}
+		}
+	}
+	return found, searched
+}
+
+// finds the cfg file named name and loads it to the default flagset
+// https://github.com/ondbyte/turbo_flag#finding-the-configuration
+func FindCfg(name string, dirs ...string) (string, error) {
+	return CommandLine.FindCfg(name, dirs...)
+}
+
+// finds the cfg file named <name>.{yaml,yml,json,toml,ini,properties,hcl} and loads it, the extensions of the formats
+// registered using RegisterCfgFormat are looked for too. it is looked for in dirs, the working
+// directory, $XDG_CONFIG_HOME (~/.config), $HOME and $XDG_CONFIG_DIRS (/etc/xdg) in this order and the first
+// one found is loaded. returns the path of the file loaded, or an error listing the places searched.
+// https://github.com/ondbyte/turbo_flag#finding-the-configuration
+func (fs *FlagSet) FindCfg(name string, dirs ...string) (string, error) {
+	found, searched := fs.findCfgFiles(name, dirs...)
+	if len(found) == 0 {
+		return "", fs.msgErr(MsgCfgNotFound, name, strings.Join(searched, ", "))
+	}
+	return found[0], fs.LoadCfg(found[0])
+}
+
+// finds all the cfg files named name and loads them merged to the default flagset
+// https://github.com/ondbyte/turbo_flag#finding-the-configuration
+func FindCfgs(name string, dirs ...string) ([]string, error) {
+	return CommandLine.FindCfgs(name, dirs...)
+}
+
+// finds the cfg files named <name>.{yaml,yml,json,toml,ini,properties,hcl} in the same places as FindCfg and loads all of them
+// using LoadCfgs, the files found in the more specific places win. returns the paths of the files loaded
+// in the order they were merged, or an error listing the places searched.
+// https://github.com/ondbyte/turbo_flag#finding-the-configuration
+func (fs *FlagSet) FindCfgs(name string, dirs ...string) ([]string, error) {
+	found, searched := fs.findCfgFiles(name, dirs...)
+	if len(found) == 0 {
+		return nil, fs.msgErr(MsgCfgNotFound, name, strings.Join(searched, ", "))
+	}
+	// the least specific file is merged first
+	for i, j := 0, len(found)-1; i < j; i, j = i+1, j-1 {
+		found[i], found[j] = found[j], found[i]
+	}
+	return found, fs.LoadCfgs(found...)
+}
@@ -0,0 +1,59 @@
+package flag_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_FindCfg(t *testing.T) {
+	home, configHome, configDir, custom := t.TempDir(), t.TempDir(), t.TempDir(), t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", configHome)
+	t.Setenv("XDG_CONFIG_DIRS", configDir)
+	write := func(dir string, name string, content string) string {
+		path := filepath.Join(dir, name)
+		err := os.WriteFile(path, []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	system := write(configDir, "findtool.toml", "host = \"system\"\nport = 1\n")
+	user := write(configHome, "findtool.json", `{"host":"user"}`)
+
+	fs := NewFlagSet("findtool", ContinueOnError)
+	host := fs.String("host", "", "", fs.Cfg("host"))
+	port := fs.Int("port", 0, "", fs.Cfg("port"))
+	path, err := fs.FindCfg("findtool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != user || *host != "user" {
+		t.Errorf("expected the file in XDG_CONFIG_HOME to be loaded, got %v host=%v", path, *host)
+	}
+
+	own := write(custom, "findtool.yaml", "port: 3\n")
+	fs = NewFlagSet("findtool", ContinueOnError)
+	host = fs.String("host", "", "", fs.Cfg("host"))
+	port = fs.Int("port", 0, "", fs.Cfg("port"))
+	paths, err := fs.FindCfgs("findtool", custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(paths, []string{system, user, own}) {
+		t.Errorf("expected the files merged from the least specific, got %v", paths)
+	}
+	if *host != "user" || *port != 3 {
+		t.Errorf("expected the more specific files to win, got host=%v port=%v", *host, *port)
+	}
+
+	_, err = NewFlagSet("findtool", ContinueOnError).FindCfg("missingtool", custom)
+	if err == nil || !strings.Contains(err.Error(), filepath.Join(custom, "missingtool.{yaml,yml,json,toml,ini,properties,hcl}")) || !strings.Contains(err.Error(), home) {
+		t.Errorf("expected the error to list the places searched, got %v", err)
+	}
+}
@@ -0,0 +1,104 @@
+package flag
+
+import (
+	"os"
+	"strings"
+)
+
+// cfgFlagCfg is the cfg flag enabled using EnableCfgFlag
+type cfgFlagCfg struct {
+	name string
+	envs []string
+}
+
+// defines a flag on the default flagset and its sub commands which loads the cfg file passed to it
+// https://github.com/ondbyte/turbo_flag#config-flag
+func EnableCfgFlag(name string, envs ...string) {
+	CommandLine.EnableCfgFlag(name, envs...)
+}
+
+// defines a flag named name on this command and its sub commands taking the path of a cfg file, like --config.
+// Parse looks for it in the arguments before parsing them, or in the envs when it is not passed, and loads
+// the file so the flags bound to a cfg get their values from it no matter where they are defined.
+// the arguments still win over the cfg.
+// https://github.com/ondbyte/turbo_flag#config-flag
+func (fs *FlagSet) EnableCfgFlag(name string, envs ...string) {
+	fs.cfgFlag = &cfgFlagCfg{name: name, envs: envs}
+	fs.inheritCfgFlag()
+}
+
+// getCfgFlag returns the cfg flag enabled on this command or the closest parent, nil if it is not enabled
+func (fs *FlagSet) getCfgFlag() *cfgFlagCfg {
+	for cmd := fs; cmd != nil; cmd = cmd.parentCmd {
+		if cmd.cfgFlag != nil {
+			return cmd.cfgFlag
+		}
+	}
+	return nil
+}
+
+// inheritCfgFlag defines the cfg flag on this command and its sub commands when it is enabled
+func (fs *FlagSet) inheritCfgFlag() {
+	cfgFlag := fs.getCfgFlag()
+	if cfgFlag == nil {
+		return
+	}
+	if _, ok := fs.formal[cfgFlag.name]; !ok {
+		fs.String(cfgFlag.name, "", EnglishFormats[MsgCfgFlagUsage], fs.Env(cfgFlag.envs...))
+		fs.formal[cfgFlag.name].usageKey = MsgCfgFlagUsage
+	}
+	for _, sc := range fs.SubCmds {
+		sc.fs.inheritCfgFlag()
+	}
+}
+
+// loadCfgFlag loads the cfg file passed to the cfg flag in args, or set in one of its envs
+func (fs *FlagSet) loadCfgFlag(args []string) error {
+	cfgFlag := fs.getCfgFlag()
+	if cfgFlag == nil {
+		return nil
+	}
+	path, ok := fs.scanFlagValue(args, cfgFlag.name)
+	if !ok {
+		for _, env := range cfgFlag.envs {
+			if path = os.Getenv(env); path != "" {
+				break
+			}
+		}
+	}
+	if path == "" {
+		return nil
+	}
+	return fs.LoadCfg(path)
+}
+
+// scanFlagValue returns the value passed to the flag named name in args without parsing them,
+// it stops where Parse would stop parsing the flags
+func (fs *FlagSet) scanFlagValue(args []string, name string) (string, bool) {
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if len(arg) < 2 || arg[0] != '-' || arg == "--" {
+			return "", false
+		}
+		argName := strings.TrimLeft(arg, "-")
+		value, hasValue := "", false
+		if eq := strings.Index(argName, "="); eq > 0 {
+			argName, value, hasValue = argName[:eq], argName[eq+1:], true
+		}
+		flag, ok := fs.formal[argName]
+		if !ok {
+			// Parse fails on it anyway
+			return "", false
+		}
+		if fs.primaryFlag(flag).Name == name {
+			if !hasValue && i+1 < len(args) {
+				value, hasValue = args[i+1], true
+			}
+			return value, hasValue
+		}
+		if !hasValue && flagTakesValue(flag) {
+			i++
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,71 @@
+package flag_test
+
+import (
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_EnableCfgFlag(t *testing.T) {
+	paths := writeCfgFiles(t,
+		[2]string{"tool.yaml", "host: from-cfg\nport: 1\n"},
+		[2]string{"env.json", `{"host":"from-env-cfg"}`},
+	)
+	newTool := func() (*FlagSet, *string, *int) {
+		fs := NewFlagSet("tool", ContinueOnError)
+		fs.EnableCfgFlag("config", "TOOL_CONFIG")
+		host := fs.String("host", "localhost", "", fs.Cfg("host"))
+		var port int
+		fs.SubCmdFs("serve", "", func(fs *FlagSet, args []string) {
+			fs.IntVar(&port, "port", 0, "", fs.Cfg("port"))
+			err := fs.Parse(args)
+			if err != nil {
+				t.Fatal(err)
+			}
+		})
+		return fs, host, &port
+	}
+
+	fs, host, _ := newTool()
+	err := fs.Parse([]string{"-config", paths[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *host != "from-cfg" {
+		t.Errorf("expected host from the cfg passed to --config, got %v", *host)
+	}
+
+	fs, host, _ = newTool()
+	err = fs.Parse([]string{"--host=from-args", "--config=" + paths[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *host != "from-args" {
+		t.Errorf("expected the args to win over the cfg, got %v", *host)
+	}
+
+	fs, _, port := newTool()
+	err = fs.Parse([]string{"serve", "--config", paths[0]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *port != 1 {
+		t.Errorf("expected the sub command to load the cfg passed to it, got %v", *port)
+	}
+
+	t.Setenv("TOOL_CONFIG", paths[1])
+	fs, host, _ = newTool()
+	err = fs.Parse(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *host != "from-env-cfg" {
+		t.Errorf("expected host from the cfg set in the env, got %v", *host)
+	}
+
+	fs, _, _ = newTool()
+	err = fs.Parse([]string{"--config", "missing.yaml"})
+	if err == nil {
+		t.Fatal("expected error for a missing cfg file")
+	}
+}
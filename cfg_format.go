@@ -0,0 +1,194 @@
+package flag
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CfgFormat reads and writes the cfg files of a format
+type CfgFormat interface {
+	// Extensions returns the extensions of the files in this format without the dot, like "yaml" and "yml"
+	Extensions() []string
+	// Decode reads the content of a file to a map
+	Decode(content string) (map[string]interface{}, error)
+	// Encode writes a map to the content of a file
+	Encode(data map[string]interface{}) (string, error)
+}
+
+type funcCfgFormat struct {
+	extensions []string
+	decode     func(content string) (map[string]interface{}, error)
+	encode     func(data map[string]interface{}) (string, error)
+}
+
+func (f funcCfgFormat) Extensions() []string { return f.extensions }
+func (f funcCfgFormat) Decode(content string) (map[string]interface{}, error) {
+	return f.decode(content)
+}
+func (f funcCfgFormat) Encode(data map[string]interface{}) (string, error) {
+	return f.encode(data)
+}
+
+// NewCfgFormat returns a CfgFormat for the files with extensions using decode and encode
+func NewCfgFormat(decode func(content string) (map[string]interface{}, error), encode func(data map[string]interface{}) (string, error), extensions ...string) CfgFormat {
+	return funcCfgFormat{extensions: extensions, decode: decode, encode: encode}
+}
+
+// the formats supported out of the box
+var (
+	YAMLFormat       = NewCfgFormat(YAMLToMap, MapToYAML, "yaml", "yml")
+	JSONFormat       = NewCfgFormat(JSONToMap, MapToJSON, "json")
+	TOMLFormat       = NewCfgFormat(TOMLToMap, MapToTOML, "toml")
+	INIFormat        = NewCfgFormat(INIToMap, MapToINI, "ini")
+	PropertiesFormat = NewCfgFormat(PropertiesToMap, MapToProperties, "properties")
+	HCLFormat        = NewCfgFormat(HCLToMap, MapToHCL, "hcl")
+)
+
+// cfgFormats are the formats of all the flagsets, the ones registered later win for the same extension
+var cfgFormats = []CfgFormat{YAMLFormat, JSONFormat, TOMLFormat, INIFormat, PropertiesFormat, HCLFormat}
+
+// registers a format for all the flagsets, it replaces the format registered earlier for the same extensions
+// https://github.com/ondbyte/turbo_flag#config-formats
+func RegisterCfgFormat(format CfgFormat) {
+	cfgFormats = append(cfgFormats, format)
+}
+
+// registers a format for this command and its sub commands, it replaces the format registered
+// earlier for the same extensions, including the ones registered for all the flagsets.
+// https://github.com/ondbyte/turbo_flag#config-formats
+func (fs *FlagSet) RegisterCfgFormat(format CfgFormat) {
+	if fs.parentCmd != nil {
+		fs.parentCmd.RegisterCfgFormat(format)
+		return
+	}
+	fs.cfgFormats = append(fs.cfgFormats, format)
+}
+
+// sets the format of the cfg files without an extension loaded to the default flagset
+// https://github.com/ondbyte/turbo_flag#config-formats
+func SetCfgFormat(ext string) {
+	CommandLine.SetCfgFormat(ext)
+}
+
+// sets the format of the cfg files without an extension, like "yaml", loading a file without an
+// extension fails unless it is set.
+// https://github.com/ondbyte/turbo_flag#config-formats
+func (fs *FlagSet) SetCfgFormat(ext string) {
+	if fs.parentCmd != nil {
+		fs.parentCmd.SetCfgFormat(ext)
+		return
+	}
+	fs.cfgFormatExt = strings.TrimPrefix(ext, ".")
+}
+
+// formats returns the formats of this command, the ones registered later win
+func (fs *FlagSet) formats() []CfgFormat {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.formats()
+	}
+	return append(append([]CfgFormat{}, cfgFormats...), fs.cfgFormats...)
+}
+
+// cfgFormat returns the format registered for the extension ext
+func (fs *FlagSet) cfgFormat(ext string) (CfgFormat, bool) {
+	formats := fs.formats()
+	for i := len(formats) - 1; i >= 0; i-- {
+		for _, formatExt := range formats[i].Extensions() {
+			if strings.EqualFold(formatExt, ext) {
+				return formats[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// cfgExtensions returns the extensions of the formats registered in the order they were registered
+func (fs *FlagSet) cfgExtensions() []string {
+	var extensions []string
+	seen := make(map[string]bool)
+	for _, format := range fs.formats() {
+		for _, ext := range format.Extensions() {
+			if !seen[strings.ToLower(ext)] {
+				seen[strings.ToLower(ext)] = true
+				extensions = append(extensions, ext)
+			}
+		}
+	}
+	return extensions
+}
+
+// formatOf returns the format of the cfg file at path, it is picked using the extension or the one set
+// using SetCfgFormat for the files without one, like stdin
+func (fs *FlagSet) formatOf(path string) (CfgFormat, error) {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.formatOf(path)
+	}
+	ext := ""
+	if path != "-" {
+		ext = strings.TrimPrefix(filepath.Ext(path), ".")
+	}
+	if ext == "" {
+		ext = fs.cfgFormatExt
+	}
+	if ext == "" && path == "-" {
+		return nil, fmt.Errorf("config read from stdin has no format, set the format using SetCfgFormat")
+	}
+	if ext == "" {
+		return nil, fmt.Errorf("config file %v has no extension, add a supported extension [%v] or set the format using SetCfgFormat", path, strings.Join(fs.cfgExtensions(), ","))
+	}
+	return fs.formatNamed(ext)
+}
+
+// formatNamed returns the format registered for the extension ext
+func (fs *FlagSet) formatNamed(ext string) (CfgFormat, error) {
+	ext = strings.TrimPrefix(ext, ".")
+	format, ok := fs.cfgFormat(ext)
+	if !ok {
+		return nil, fmt.Errorf("unsupported extension %v, supported extensions are [%v]", ext, strings.Join(fs.cfgExtensions(), ","))
+	}
+	return format, nil
+}
+
+// readCfgFile reads the cfg file at path to a map, "-" reads stdin
+func (fs *FlagSet) readCfgFile(path string) (map[string]interface{}, error) {
+	format, err := fs.formatOf(path)
+	if err != nil {
+		return nil, err
+	}
+	var b []byte
+	if path == "-" {
+		b, err = io.ReadAll(os.Stdin)
+	} else {
+		b, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file at %v : %v", path, err)
+	}
+	return fs.decodeCfg(format, b)
+}
+
+// decodeCfg decodes the content of a cfg to a map, expanding the envs in it when it is enabled
+func (fs *FlagSet) decodeCfg(format CfgFormat, content []byte) (map[string]interface{}, error) {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.decodeCfg(format, content)
+	}
+	mapContent, err := format.Decode(string(content))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file : %v", err)
+	}
+	if mapContent == nil {
+		// like an empty file
+		mapContent = make(map[string]interface{})
+	}
+	if !fs.expandEnv {
+		return mapContent, nil
+	}
+	expanded, err := expandEnvValues(mapContent, "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read config file : %v", err)
+	}
+	return expanded.(map[string]interface{}), nil
+}
@@ -0,0 +1,57 @@
+package flag_test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_RegisterCfgFormat(t *testing.T) {
+	paths := writeCfgFiles(t,
+		[2]string{"tool.conf", "database.password=12345\n"},
+		[2]string{"toolrc", "database:\n  password: abcde\n"},
+	)
+	// reads dotted key=value lines like .properties
+	conf := NewCfgFormat(PropertiesToMap, MapToProperties, "conf")
+
+	fs := NewFlagSet("tool", ContinueOnError)
+	password := fs.String("password", "", "", fs.Cfg("database.password"))
+	err := fs.LoadCfg(paths[0])
+	if err == nil || !strings.Contains(err.Error(), "unsupported extension conf") {
+		t.Fatalf("expected the unregistered extension to fail, got %v", err)
+	}
+	fs.RegisterCfgFormat(conf)
+	err = fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *password != "12345" {
+		t.Errorf("expected the password from the registered format, got %v", *password)
+	}
+
+	found, err := fs.FindCfg("tool", filepath.Dir(paths[0]))
+	if err != nil || found != paths[0] {
+		t.Errorf("expected FindCfg to look for the registered extension, got %v : %v", found, err)
+	}
+
+	err = fs.LoadCfg(paths[1])
+	if err == nil || !strings.Contains(err.Error(), "has no extension") {
+		t.Fatalf("expected the file without extension to fail, got %v", err)
+	}
+	fs.SetCfgFormat("yaml")
+	err = fs.LoadCfg(paths[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *password != "abcde" {
+		t.Errorf("expected the password from the file without extension, got %v", *password)
+	}
+
+	other := NewFlagSet("other", ContinueOnError)
+	err = other.LoadCfg(paths[0])
+	if err == nil {
+		t.Error("expected the format registered on a flagset to not be used by the others")
+	}
+}
@@ -0,0 +1,214 @@
+package flag
+
+import (
+	"sort"
+	"time"
+)
+
+// cfgValues returns the values at the dot notation in the cfg, more than one when it has a wildcard
+func (fs *FlagSet) cfgValues(notation string) []interface{} {
+	segments, err := parseNotation(notation)
+	if err != nil {
+		return nil
+	}
+	var values []interface{}
+	fs.View(func() {
+		values = lookupNotation(fs.cfg, segments)
+	})
+	return values
+}
+
+// cfgValueText returns the value at the dot notation in the cfg as text, the text the flags bound to it are set with
+func (fs *FlagSet) cfgValueText(notation string) (string, bool) {
+	values := fs.cfgValues(notation)
+	if len(values) == 0 {
+		return "", false
+	}
+	text, err := jsonnify(values[0])
+	return text, err == nil
+}
+
+// returns the value at the dot notation in the cfg of the default flagset as a string
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func GetString(notation string) string {
+	return CommandLine.GetString(notation)
+}
+
+// returns the value at the dot notation in the cfg as a string, the maps and arrays are returned as JSON.
+// it is empty when the value is not in the cfg. the Get methods read the cfg using the same dot
+// notation as Cfg, including the defaults of the flags bound to it, and convert the value like
+// the flag of the type would. they take the lock View takes, don't call them inside View.
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) GetString(notation string) string {
+	text, _ := fs.cfgValueText(notation)
+	return text
+}
+
+// returns the value at the dot notation in the cfg of the default flagset as an int
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func GetInt(notation string) int {
+	return CommandLine.GetInt(notation)
+}
+
+// returns the value at the dot notation in the cfg as an int, 0 when it is not in the cfg or not an int
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) GetInt(notation string) int {
+	var value int
+	if text, ok := fs.cfgValueText(notation); ok {
+		newIntValue(0, &value).Set(text)
+	}
+	return value
+}
+
+// returns the value at the dot notation in the cfg of the default flagset as a bool
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func GetBool(notation string) bool {
+	return CommandLine.GetBool(notation)
+}
+
+// returns the value at the dot notation in the cfg as a bool, false when it is not in the cfg or not a bool
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) GetBool(notation string) bool {
+	var value bool
+	if text, ok := fs.cfgValueText(notation); ok {
+		newBoolValue(false, &value).Set(text)
+	}
+	return value
+}
+
+// returns the value at the dot notation in the cfg of the default flagset as a duration
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func GetDuration(notation string) time.Duration {
+	return CommandLine.GetDuration(notation)
+}
+
+// returns the value at the dot notation in the cfg as a duration like "1m30s", 0 when it is not in the cfg
+// or not a duration
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) GetDuration(notation string) time.Duration {
+	var value time.Duration
+	if text, ok := fs.cfgValueText(notation); ok {
+		newDurationValue(0, &value).Set(text)
+	}
+	return value
+}
+
+// returns the values at the dot notation in the cfg of the default flagset as strings
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func GetStringSlice(notation string) []string {
+	return CommandLine.GetStringSlice(notation)
+}
+
+// returns the values at the dot notation in the cfg as strings, the elements when the value is an array,
+// all the values matched when the notation has a wildcard like servers.*.host. nil when it is not in the cfg.
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) GetStringSlice(notation string) []string {
+	values := fs.cfgValues(notation)
+	if len(values) == 1 {
+		if array, ok := values[0].([]interface{}); ok {
+			values = array
+		}
+	}
+	var texts []string
+	for _, value := range values {
+		if text, err := jsonnify(value); err == nil {
+			texts = append(texts, text)
+		}
+	}
+	return texts
+}
+
+// returns the map at the dot notation in the cfg of the default flagset
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func GetStringMap(notation string) map[string]interface{} {
+	return CommandLine.GetStringMap(notation)
+}
+
+// returns a copy of the map at the dot notation in the cfg, nil when it is not in the cfg or not a map
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) GetStringMap(notation string) map[string]interface{} {
+	segments, err := parseNotation(notation)
+	if err != nil {
+		return nil
+	}
+	var copied map[string]interface{}
+	fs.View(func() {
+		values := lookupNotation(fs.cfg, segments)
+		if len(values) == 0 {
+			return
+		}
+		if _, err := stringMap(values[0]); err == nil {
+			// copied while reloading the cfg can't change it
+			copied = copyCfgValue(values[0]).(map[string]interface{})
+		}
+	})
+	return copied
+}
+
+// reports whether the cfg of the default flagset has a value at the dot notation
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func IsSet(notation string) bool {
+	return CommandLine.IsSet(notation)
+}
+
+// reports whether the cfg has a value at the dot notation, it is true for the defaults of the flags bound
+// to the cfg too, use CfgSource to know whether it is loaded from a file
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) IsSet(notation string) bool {
+	return len(fs.cfgValues(notation)) > 0
+}
+
+// returns the dot notations of all the values in the cfg of the default flagset
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func AllKeys() []string {
+	return CommandLine.AllKeys()
+}
+
+// returns the dot notations of all the values in the cfg sorted, the maps are walked into
+// and the arrays are returned as one value
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) AllKeys() []string {
+	var notations []string
+	fs.View(func() {
+		notations = leafNotations(fs.cfg, "", notations)
+	})
+	sort.Strings(notations)
+	return notations
+}
+
+func leafNotations(m map[string]interface{}, prefix string, notations []string) []string {
+	for key, value := range m {
+		notation := joinNotation(prefix, key)
+		if sub, err := stringMap(value); err == nil && len(sub) > 0 {
+			notations = leafNotations(sub, notation, notations)
+			continue
+		}
+		notations = append(notations, notation)
+	}
+	return notations
+}
+
+// returns a flagset holding a copy of the cfg at the dot notation prefix of the default flagset
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func Sub(prefix string) *FlagSet {
+	return CommandLine.Sub(prefix)
+}
+
+// returns a new flagset named prefix holding a copy of the map at the dot notation prefix in the cfg, so
+// its values can be read or bound without the prefix. its cfg is empty when there is no map at prefix,
+// an empty prefix copies the whole cfg to read many values from the same cfg while it is hot reloaded.
+// https://github.com/ondbyte/turbo_flag#reading-the-configuration
+func (fs *FlagSet) Sub(prefix string) *FlagSet {
+	sub := NewFlagSet(prefix, ContinueOnError)
+	if prefix == "" {
+		fs.View(func() {
+			sub.cfg = copyCfgValue(fs.cfg).(map[string]interface{})
+		})
+		return sub
+	}
+	sub.cfg = fs.GetStringMap(prefix)
+	if sub.cfg == nil {
+		sub.cfg = make(map[string]interface{})
+	}
+	return sub
+}
@@ -0,0 +1,93 @@
+package flag_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_GetCfg(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.LoadCfgBytes([]byte(`
+name: tool
+workers: "0x10"
+debug: true
+timeout: 1m30s
+tags: [a, b]
+servers:
+  - host: a
+  - host: b
+database:
+  host: localhost
+  port: 5432
+`), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.Int("retries", 3, "", fs.Cfg("client.retries"))
+
+	if fs.GetString("name") != "tool" || fs.GetString("missing") != "" {
+		t.Errorf("unexpected strings %q %q", fs.GetString("name"), fs.GetString("missing"))
+	}
+	if fs.GetString("database") != `{"host":"localhost","port":5432}` {
+		t.Errorf("expected the map as JSON, got %v", fs.GetString("database"))
+	}
+	if fs.GetString("tags") != `["a","b"]` {
+		t.Errorf("expected the array as JSON, got %v", fs.GetString("tags"))
+	}
+	if fs.GetInt("workers") != 16 || fs.GetInt("database.port") != 5432 || fs.GetInt("name") != 0 {
+		t.Errorf("unexpected ints %v %v %v", fs.GetInt("workers"), fs.GetInt("database.port"), fs.GetInt("name"))
+	}
+	if !fs.GetBool("debug") || fs.GetBool("name") {
+		t.Errorf("unexpected bools %v %v", fs.GetBool("debug"), fs.GetBool("name"))
+	}
+	if fs.GetDuration("timeout") != 90*time.Second {
+		t.Errorf("unexpected duration %v", fs.GetDuration("timeout"))
+	}
+	if !reflect.DeepEqual(fs.GetStringSlice("tags"), []string{"a", "b"}) || !reflect.DeepEqual(fs.GetStringSlice("servers.*.host"), []string{"a", "b"}) {
+		t.Errorf("unexpected slices %v %v", fs.GetStringSlice("tags"), fs.GetStringSlice("servers.*.host"))
+	}
+	if !reflect.DeepEqual(fs.GetStringMap("database"), map[string]interface{}{"host": "localhost", "port": 5432}) {
+		t.Errorf("unexpected map %v", fs.GetStringMap("database"))
+	}
+	if !fs.IsSet("servers[1].host") || fs.IsSet("servers[2].host") || !fs.IsSet("client.retries") {
+		t.Errorf("unexpected IsSet %v %v %v", fs.IsSet("servers[1].host"), fs.IsSet("servers[2].host"), fs.IsSet("client.retries"))
+	}
+	want := []string{"client.retries", "database.host", "database.port", "debug", "name", "servers", "tags", "timeout", "workers"}
+	if !reflect.DeepEqual(fs.AllKeys(), want) {
+		t.Errorf("expected the keys %v, got %v", want, fs.AllKeys())
+	}
+
+	database := fs.Sub("database")
+	port := database.Int("port", 0, "", database.Cfg("port"))
+	if database.GetString("host") != "localhost" || *port != 5432 {
+		t.Errorf("expected the values under the prefix, got host=%v port=%v", database.GetString("host"), *port)
+	}
+	fs.GetStringMap("database")["host"] = "changed"
+	if fs.GetString("database.host") != "localhost" {
+		t.Error("expected the map to be a copy")
+	}
+	if len(fs.Sub("missing").AllKeys()) != 0 || fs.Sub("").GetString("database.host") != "localhost" {
+		t.Error("unexpected sub cfgs")
+	}
+}
+
+func TestFlagSet_GetCfgCopiesArrays(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.LoadCfgBytes([]byte("cluster:\n  tags: [a]\n  servers:\n    - host: a\n"), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cluster := fs.GetStringMap("cluster")
+	cluster["tags"].([]interface{})[0] = "changed"
+	cluster["servers"].([]interface{})[0].(map[string]interface{})["host"] = "changed"
+	sub := fs.Sub("")
+	sub.GetStringMap("cluster")["tags"].([]interface{})[0] = "changed"
+	// binding a flag writes its default to the cfg of sub
+	sub.Int("port", 80, "", sub.Cfg("cluster.servers.0.port"))
+	if fs.GetString("cluster.tags.0") != "a" || fs.GetString("cluster.servers.0.host") != "a" || fs.IsSet("cluster.servers.0.port") {
+		t.Errorf("expected the arrays to be copies, got %v", fs.GetStringMap("cluster"))
+	}
+}
@@ -0,0 +1,86 @@
+package flag
+
+import (
+	"io"
+	iofs "io/fs"
+)
+
+// loads the cfg from r in the format like "yaml" to the default flagset
+// https://github.com/ondbyte/turbo_flag#loading-from-anywhere
+func LoadCfgReader(r io.Reader, format string) error {
+	return CommandLine.LoadCfgReader(r, format)
+}
+
+// loads the cfg from r in the format like "yaml", the one set using SetCfgFormat is used when format is empty.
+// it replaces the loaded cfg like LoadCfg, the values loaded have no CfgSource.
+// https://github.com/ondbyte/turbo_flag#loading-from-anywhere
+func (fs *FlagSet) LoadCfgReader(r io.Reader, format string) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.LoadCfgReader(r, format)
+	}
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return fs.msgErr(MsgCfgReadFailed, err)
+	}
+	return fs.LoadCfgBytes(b, format)
+}
+
+// loads the cfg from b in the format like "yaml" to the default flagset
+// https://github.com/ondbyte/turbo_flag#loading-from-anywhere
+func LoadCfgBytes(b []byte, format string) error {
+	return CommandLine.LoadCfgBytes(b, format)
+}
+
+// loads the cfg from b in the format like "yaml", the one set using SetCfgFormat is used when format is empty.
+// it replaces the loaded cfg like LoadCfg, the values loaded have no CfgSource.
+// https://github.com/ondbyte/turbo_flag#loading-from-anywhere
+func (fs *FlagSet) LoadCfgBytes(b []byte, format string) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.LoadCfgBytes(b, format)
+	}
+	if format == "" {
+		format = fs.cfgFormatExt
+	}
+	if format == "" {
+		return fs.msgErr(MsgCfgFormatEmpty)
+	}
+	cfgFormat, err := fs.formatNamed(format)
+	if err != nil {
+		return err
+	}
+	content, err := fs.decodeCfg(cfgFormat, b)
+	if err != nil {
+		return err
+	}
+	fs.setCfg(content, "", nil)
+	return nil
+}
+
+// loads the cfg file at path in fsys to the default flagset
+// https://github.com/ondbyte/turbo_flag#loading-from-anywhere
+func LoadCfgFS(fsys iofs.FS, path string) error {
+	return CommandLine.LoadCfgFS(fsys, path)
+}
+
+// loads the cfg file at path in fsys, like the defaults embedded using an embed.FS, the format is picked
+// like LoadCfg. it replaces the loaded cfg like LoadCfg, the CfgSource of the values loaded is path.
+// https://github.com/ondbyte/turbo_flag#loading-from-anywhere
+func (fs *FlagSet) LoadCfgFS(fsys iofs.FS, path string) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.LoadCfgFS(fsys, path)
+	}
+	format, err := fs.formatOf(path)
+	if err != nil {
+		return err
+	}
+	b, err := iofs.ReadFile(fsys, path)
+	if err != nil {
+		return fs.msgErr(MsgCfgFileReadFailed, path, err)
+	}
+	content, err := fs.decodeCfg(format, b)
+	if err != nil {
+		return err
+	}
+	fs.setCfg(content, path, nil)
+	return nil
+}
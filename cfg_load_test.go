@@ -0,0 +1,83 @@
+package flag_test
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_LoadCfgReader(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	password := fs.String("password", "", "", fs.Cfg("database.password"))
+	err := fs.LoadCfgReader(strings.NewReader("database:\n  password: reader\n"), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *password != "reader" {
+		t.Errorf("expected the password from the reader, got %v", *password)
+	}
+	err = fs.LoadCfgBytes([]byte(`{"database":{"password":"bytes"}}`), ".json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *password != "bytes" || fs.CfgSource("database.password") != "" {
+		t.Errorf("expected the password from the bytes without a source, got %v from %q", *password, fs.CfgSource("database.password"))
+	}
+	err = fs.LoadCfgBytes([]byte("password = 1"), "")
+	if err == nil {
+		t.Error("expected error without a format")
+	}
+}
+
+func TestFlagSet_LoadCfgFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"defaults/tool.toml": {Data: []byte("[database]\npassword = \"embedded\"\n")},
+	}
+	fs := NewFlagSet("tool", ContinueOnError)
+	password := fs.String("password", "", "", fs.Cfg("database.password"))
+	err := fs.LoadCfgFS(fsys, "defaults/tool.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *password != "embedded" || fs.CfgSource("database.password") != "defaults/tool.toml" {
+		t.Errorf("expected the password from the fs, got %v from %q", *password, fs.CfgSource("database.password"))
+	}
+	err = fs.LoadCfgFS(fsys, "defaults/missing.toml")
+	if err == nil {
+		t.Error("expected error for a missing file")
+	}
+}
+
+func TestFlagSet_LoadCfgStdin(t *testing.T) {
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdin := os.Stdin
+	os.Stdin = r
+	defer func() { os.Stdin = stdin }()
+	_, err = w.WriteString("database.password=stdin\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	w.Close()
+
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.EnableCfgFlag("config")
+	password := fs.String("password", "", "", fs.Cfg("database.password"))
+	err = fs.Parse([]string{"--config", "-"})
+	if err == nil || !strings.Contains(err.Error(), "stdin has no format") {
+		t.Fatalf("expected error for stdin without a format, got %v", err)
+	}
+	fs.SetCfgFormat("properties")
+	err = fs.Parse([]string{"--config", "-"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *password != "stdin" {
+		t.Errorf("expected the password from stdin, got %v", *password)
+	}
+}
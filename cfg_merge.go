@@ -0,0 +1,196 @@
+package flag
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ArrayMerge decides how the arrays of two configurations are merged
+type ArrayMerge int
+
+const (
+	// ArrayReplace replaces the array of the earlier configuration with the one of the later configuration
+	ArrayReplace ArrayMerge = iota
+	// ArrayAppend appends the array of the later configuration to the one of the earlier configuration
+	ArrayAppend
+)
+
+// deepMerge merges src into dst, maps are merged key by key, arrays are merged using strategy
+// and every other value of src replaces the one in dst. source is recorded in sources for
+// the dot notation of every value taken from src.
+func deepMerge(dst map[string]interface{}, src map[string]interface{}, strategy ArrayMerge, source string, sources map[string]string, prefix string) {
+	for key, srcValue := range src {
+		notation := prefix + notationKey(key)
+		if m, err := stringMap(srcValue); err == nil {
+			srcValue = m
+		}
+		srcMap, srcIsMap := srcValue.(map[string]interface{})
+		dstMap, dstIsMap := dst[key].(map[string]interface{})
+		if srcIsMap && dstIsMap {
+			deepMerge(dstMap, srcMap, strategy, source, sources, notation+".")
+			continue
+		}
+		forgetCfgSources(sources, notation)
+		if srcIsMap {
+			// copy so merging into it later doesn't change src
+			dstMap = make(map[string]interface{})
+			deepMerge(dstMap, srcMap, strategy, source, sources, notation+".")
+			dst[key] = dstMap
+			continue
+		}
+		srcArray, srcIsArray := srcValue.([]interface{})
+		dstArray, dstIsArray := dst[key].([]interface{})
+		if srcIsArray && dstIsArray && strategy == ArrayAppend {
+			merged := make([]interface{}, 0, len(dstArray)+len(srcArray))
+			srcValue = append(append(merged, dstArray...), srcArray...)
+		}
+		dst[key] = srcValue
+		sources[notation] = source
+	}
+}
+
+// copyCfgValue returns a copy of v with the maps and arrays in it copied, so changing the copy doesn't change v
+func copyCfgValue(v interface{}) interface{} {
+	if m, err := stringMap(v); err == nil {
+		copied := make(map[string]interface{}, len(m))
+		for key, value := range m {
+			copied[key] = copyCfgValue(value)
+		}
+		return copied
+	}
+	switch v := v.(type) {
+	case []interface{}:
+		copied := make([]interface{}, len(v))
+		for i, value := range v {
+			copied[i] = copyCfgValue(value)
+		}
+		return copied
+	case []map[string]interface{}:
+		copied := make([]interface{}, len(v))
+		for i, value := range v {
+			copied[i] = copyCfgValue(value)
+		}
+		return copied
+	}
+	return v
+}
+
+// forgetCfgSources removes the sources recorded for notation and the values under it
+func forgetCfgSources(sources map[string]string, notation string) {
+	for key := range sources {
+		if key == notation || strings.HasPrefix(key, notation+".") {
+			delete(sources, key)
+		}
+	}
+}
+
+// loads the cfg files at paths to the default flagset merging them in order
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func LoadCfgs(paths ...string) error {
+	return CommandLine.LoadCfgs(paths...)
+}
+
+// loads the cfg files at paths merging them in order, values of a later file win over the ones of
+// the earlier files key by key, arrays are merged as set using SetArrayMerge.
+// the flags bound to a cfg are set again unless they are set from the arguments. "-" reads a cfg from stdin.
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func (fs *FlagSet) LoadCfgs(paths ...string) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.LoadCfgs(paths...)
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("no paths passed while loading configs")
+	}
+	cfg, sources, err := fs.readCfgFiles(paths)
+	if err != nil {
+		return err
+	}
+	fs.cfgMu.Lock()
+	defer fs.cfgMu.Unlock()
+	fs.cfgPath = paths[len(paths)-1]
+	fs.cfgPaths = paths
+	fs.cfg = cfg
+	fs.cfgSources = sources
+	bindCfgRecursiveAfterLoadCfg(fs)
+	return nil
+}
+
+// readCfgFiles reads the cfg files at paths merged in order along with the path each dot notation came from
+func (fs *FlagSet) readCfgFiles(paths []string) (map[string]interface{}, map[string]string, error) {
+	contents := make([]map[string]interface{}, len(paths))
+	for i, path := range paths {
+		content, err := fs.readCfgFile(path)
+		if err != nil {
+			return nil, nil, err
+		}
+		contents[i] = content
+	}
+	cfg := make(map[string]interface{})
+	sources := make(map[string]string)
+	for i, content := range contents {
+		deepMerge(cfg, content, fs.arrayMerge, paths[i], sources, "")
+	}
+	return cfg, sources, nil
+}
+
+// merges the cfg file at path into the cfg of the default flagset
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func MergeCfg(path string) error {
+	return CommandLine.MergeCfg(path)
+}
+
+// merges the cfg file at path into the cfg loaded already, its values win over the loaded ones key by key,
+// arrays are merged as set using SetArrayMerge. the flags bound to a cfg are set again unless they are set
+// from the arguments.
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func (fs *FlagSet) MergeCfg(path string) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.MergeCfg(path)
+	}
+	content, err := fs.readCfgFile(path)
+	if err != nil {
+		return err
+	}
+	fs.cfgMu.Lock()
+	defer fs.cfgMu.Unlock()
+	if fs.cfgSources == nil {
+		fs.cfgSources = make(map[string]string)
+	}
+	deepMerge(fs.cfg, content, fs.arrayMerge, path, fs.cfgSources, "")
+	fs.cfgPath = path
+	fs.cfgPaths = append(fs.cfgPaths, path)
+	bindCfgRecursiveAfterLoadCfg(fs)
+	return nil
+}
+
+// sets how the arrays are merged by LoadCfgs and MergeCfg in the default flagset
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func SetArrayMerge(strategy ArrayMerge) {
+	CommandLine.SetArrayMerge(strategy)
+}
+
+// sets how the arrays are merged by LoadCfgs and MergeCfg, ArrayReplace by default
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func (fs *FlagSet) SetArrayMerge(strategy ArrayMerge) {
+	if fs.parentCmd != nil {
+		fs.parentCmd.SetArrayMerge(strategy)
+		return
+	}
+	fs.arrayMerge = strategy
+}
+
+// returns the path of the cfg file the value at the dot notation came from in the default flagset
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func CfgSource(notation string) string {
+	return CommandLine.CfgSource(notation)
+}
+
+// returns the path of the cfg file the value at the dot notation came from, empty when the value
+// is not loaded from a file, like the defaults of the flags bound to a cfg.
+// https://github.com/ondbyte/turbo_flag#layered-configurations
+func (fs *FlagSet) CfgSource(notation string) string {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.CfgSource(notation)
+	}
+	return fs.cfgSources[notation]
+}
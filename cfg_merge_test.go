@@ -0,0 +1,113 @@
+package flag_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+// writeCfgFiles writes the files to a temporary directory and returns their paths in the same order
+func writeCfgFiles(t *testing.T, files ...[2]string) []string {
+	t.Helper()
+	dir := t.TempDir()
+	var paths []string
+	for _, file := range files {
+		path := filepath.Join(dir, file[0])
+		err := os.WriteFile(path, []byte(file[1]), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+	return paths
+}
+
+func TestFlagSet_LoadCfgs(t *testing.T) {
+	paths := writeCfgFiles(t,
+		[2]string{"system.yaml", "database:\n  host: db.internal\n  port: 5432\nservers: [a, b]\n"},
+		[2]string{"user.json", `{"database":{"port":6543},"servers":["c"]}`},
+		[2]string{"local.toml", "[database]\nuser = \"yadu\"\n"},
+	)
+	for _, tt := range []struct {
+		strategy ArrayMerge
+		servers  string
+	}{
+		{ArrayReplace, `["c"]`},
+		{ArrayAppend, `["a","b","c"]`},
+	} {
+		fs := NewFlagSet("tool", ContinueOnError)
+		var port *int
+		fs.SubCmdFs("serve", "", func(fs *FlagSet, args []string) {
+			port = fs.Int("port", 0, "", fs.Cfg("database.port"))
+			fs.Parse(args)
+		})
+		host := fs.String("host", "", "", fs.Cfg("database.host"))
+		user := fs.String("user", "", "", fs.Cfg("database.user"))
+		servers := fs.String("servers", "", "", fs.Cfg("servers"))
+		fs.SetArrayMerge(tt.strategy)
+		err := fs.LoadCfgs(paths...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *host != "db.internal" || *user != "yadu" || *servers != tt.servers {
+			t.Errorf("expected the merged values, got host=%v user=%v servers=%v", *host, *user, *servers)
+		}
+		err = fs.Parse([]string{"serve"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if *port != 6543 {
+			t.Errorf("expected the port of the later file, got %v", *port)
+		}
+		sources := map[string]string{
+			"database.host": fs.CfgSource("database.host"),
+			"database.port": fs.CfgSource("database.port"),
+			"database.user": fs.CfgSource("database.user"),
+			"servers":       fs.CfgSource("servers"),
+		}
+		want := map[string]string{
+			"database.host": paths[0],
+			"database.port": paths[1],
+			"database.user": paths[2],
+			"servers":       paths[1],
+		}
+		if !reflect.DeepEqual(sources, want) {
+			t.Errorf("expected the sources %v, got %v", want, sources)
+		}
+	}
+	err := NewFlagSet("tool", ContinueOnError).LoadCfgs(paths[0], "missing.yaml")
+	if err == nil {
+		t.Fatal("expected error for a missing file")
+	}
+}
+
+func TestFlagSet_MergeCfg(t *testing.T) {
+	paths := writeCfgFiles(t,
+		[2]string{"base.yaml", "database:\n  host: db.internal\n  port: 5432\n"},
+		[2]string{"override.yaml", "database:\n  host: localhost\n"},
+	)
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := fs.String("host", "", "", fs.Cfg("database.host"))
+	port := fs.Int("port", 0, "", fs.Cfg("database.port"))
+	err = fs.Parse([]string{"--port", "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = fs.MergeCfg(paths[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *host != "localhost" || *port != 1 {
+		t.Errorf("expected host from the merged file and port from the args, got host=%v port=%v", *host, *port)
+	}
+	if fs.CfgSource("database.host") != paths[1] || fs.CfgSource("database.port") != paths[0] {
+		t.Errorf("unexpected sources %v %v", fs.CfgSource("database.host"), fs.CfgSource("database.port"))
+	}
+}
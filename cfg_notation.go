@@ -0,0 +1,247 @@
+package flag
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// notationSegment is a segment of a dot notation like servers, 0, [0], "example.com" or *
+type notationSegment struct {
+	key      string
+	index    int  // the number of a numeric segment, -1 otherwise
+	bracket  bool // the segment is a number in brackets like [0], it creates an array while setting
+	wildcard bool // the segment matches all the elements of an array or the values of a map
+}
+
+// parseNotation parses a dot notation like servers[0].host, servers.0.host, "example.com".port,
+// ports["example.com"] or servers.*.host to its segments
+func parseNotation(notation string) ([]notationSegment, error) {
+	var segments []notationSegment
+	for i := 0; i < len(notation); {
+		var segment notationSegment
+		switch notation[i] {
+		case '[':
+			end := strings.IndexByte(notation[i:], ']')
+			inner := ""
+			if end > 0 {
+				inner = notation[i+1 : i+end]
+			}
+			if len(inner) > 0 && (inner[0] == '"' || inner[0] == '\'') {
+				key, n, err := parseQuoted(notation[i+1:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid notation %v : %v", notation, err)
+				}
+				if i+1+n >= len(notation) || notation[i+1+n] != ']' {
+					return nil, fmt.Errorf("invalid notation %v : missing ]", notation)
+				}
+				segment = notationSegment{key: key, index: -1}
+				i += n + 2
+				break
+			}
+			if end < 0 {
+				return nil, fmt.Errorf("invalid notation %v : missing ]", notation)
+			}
+			segment = plainSegment(inner)
+			segment.bracket = segment.index >= 0
+			if inner == "" || (segment.index < 0 && !segment.wildcard) {
+				return nil, fmt.Errorf("invalid notation %v : brackets need a number, * or a quoted key", notation)
+			}
+			i += end + 1
+		case '"', '\'':
+			key, n, err := parseQuoted(notation[i:])
+			if err != nil {
+				return nil, fmt.Errorf("invalid notation %v : %v", notation, err)
+			}
+			segment = notationSegment{key: key, index: -1}
+			i += n
+		default:
+			end := strings.IndexAny(notation[i:], ".[")
+			if end < 0 {
+				end = len(notation) - i
+			}
+			segment = plainSegment(notation[i : i+end])
+			i += end
+		}
+		segments = append(segments, segment)
+		if i < len(notation) && notation[i] == '.' {
+			i++
+			if i == len(notation) {
+				// like a trailing dot, the key is empty
+				segments = append(segments, notationSegment{index: -1})
+			}
+		}
+	}
+	if len(segments) == 0 {
+		segments = append(segments, notationSegment{index: -1})
+	}
+	return segments, nil
+}
+
+func plainSegment(key string) notationSegment {
+	if key == "*" {
+		return notationSegment{key: key, index: -1, wildcard: true}
+	}
+	index, err := strconv.Atoi(key)
+	if err != nil || index < 0 || strings.HasPrefix(key, "+") {
+		index = -1
+	}
+	return notationSegment{key: key, index: index}
+}
+
+// literalSegments splits key on the dots only, the brackets, quotes and wildcards stay in the keys,
+// for the formats like INI and properties whose keys are not dot notations
+func literalSegments(key string) []notationSegment {
+	keys := strings.Split(key, ".")
+	segments := make([]notationSegment, len(keys))
+	for i, key := range keys {
+		segments[i] = notationSegment{key: key, index: -1}
+	}
+	return segments
+}
+
+// parseQuoted parses the quoted key at the start of s, double quoted keys can have the escapes of Go strings,
+// it returns the key and the length of it in s including the quotes
+func parseQuoted(s string) (string, int, error) {
+	quote := s[0]
+	for i := 1; i < len(s); i++ {
+		if s[i] == '\\' && quote == '"' {
+			i++
+			continue
+		}
+		if s[i] != quote {
+			continue
+		}
+		if quote == '\'' {
+			return s[1:i], i + 1, nil
+		}
+		key, err := strconv.Unquote(s[:i+1])
+		return key, i + 1, err
+	}
+	return "", 0, fmt.Errorf("missing closing %c", quote)
+}
+
+// notationKey returns key as a segment of a dot notation, quoted when it is not a plain key
+func notationKey(key string) string {
+	if key == "" || key == "*" || strings.ContainsAny(key, ".[]\"'") {
+		return strconv.Quote(key)
+	}
+	return key
+}
+
+// lookupNotation returns the values at the segments in data, more than one when a segment is a wildcard
+func lookupNotation(data interface{}, segments []notationSegment) []interface{} {
+	if len(segments) == 0 {
+		return []interface{}{data}
+	}
+	segment, rest := segments[0], segments[1:]
+	if array, ok := data.([]interface{}); ok {
+		if segment.wildcard {
+			var values []interface{}
+			for _, element := range array {
+				values = append(values, lookupNotation(element, rest)...)
+			}
+			return values
+		}
+		if segment.index >= 0 && segment.index < len(array) {
+			return lookupNotation(array[segment.index], rest)
+		}
+		return nil
+	}
+	m, err := stringMap(data)
+	if err != nil {
+		return nil
+	}
+	if segment.wildcard {
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var values []interface{}
+		for _, key := range keys {
+			values = append(values, lookupNotation(m[key], rest)...)
+		}
+		return values
+	}
+	value, ok := m[segment.key]
+	if !ok {
+		return nil
+	}
+	return lookupNotation(value, rest)
+}
+
+// setNotation sets value at the segments in container and returns container, the missing maps and arrays are created
+func setNotation(container interface{}, segments []notationSegment, value interface{}) (interface{}, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+	segment, rest := segments[0], segments[1:]
+	if segment.wildcard {
+		return nil, fmt.Errorf("unable to set a value at a wildcard")
+	}
+	array, isArray := container.([]interface{})
+	if isArray && segment.index >= 0 || !isArray && segment.bracket && container == nil {
+		for len(array) <= segment.index {
+			array = append(array, nil)
+		}
+		element, err := setNotation(array[segment.index], rest, value)
+		if err != nil {
+			return nil, err
+		}
+		array[segment.index] = element
+		return array, nil
+	}
+	m, ok := container.(map[string]interface{})
+	if !ok && container == nil {
+		m = make(map[string]interface{})
+	} else if !ok {
+		var err error
+		if m, err = stringMap(container); err != nil {
+			// replacing the value would lose it, like an array a flag bound to servers.name is written to
+			return nil, fmt.Errorf("unable to set the key %v in a value which is not a map", segment.key)
+		}
+	}
+	element, err := setNotation(m[segment.key], rest, value)
+	if err != nil {
+		return nil, err
+	}
+	m[segment.key] = element
+	return m, nil
+}
+
+// getValuesByDotNotation returns the values at the dot notation in inputMap as text, more than one when the
+// notation has a wildcard
+func getValuesByDotNotation(inputMap map[string]interface{}, notation string) ([]string, error) {
+	segments, err := parseNotation(notation)
+	if err != nil {
+		return nil, err
+	}
+	values := lookupNotation(inputMap, segments)
+	if len(values) == 0 {
+		return nil, fmt.Errorf("value not found for dot notation")
+	}
+	texts := make([]string, len(values))
+	for i, value := range values {
+		texts[i], err = jsonnify(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return texts, nil
+}
+
+// hasWildcard reports whether the dot notation has a wildcard segment
+func hasWildcard(notation string) bool {
+	segments, err := parseNotation(notation)
+	if err != nil {
+		return false
+	}
+	for _, segment := range segments {
+		if segment.wildcard {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+package flag_test
+
+import (
+	"strings"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+// appendValue appends every value set to it
+type appendValue []string
+
+func (a *appendValue) String() string     { return strings.Join(*a, ",") }
+func (a *appendValue) Set(s string) error { *a = append(*a, s); return nil }
+
+// replaceValue is appendValue whose values are replaced by the wildcard bindings
+type replaceValue struct{ appendValue }
+
+func (r *replaceValue) Replace(values []string) error {
+	r.appendValue = append(appendValue{}, values...)
+	return nil
+}
+
+func TestFlagSet_CfgNotation(t *testing.T) {
+	paths := writeCfgFiles(t, [2]string{"tool.yaml", `
+servers:
+  - host: a
+    port: 1
+  - host: b
+    port: 2
+example.com:
+  port: 443
+`})
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	first := fs.String("first", "", "", fs.Cfg("servers[0].host"))
+	port := fs.Int("port", 0, "", fs.Cfg(`"example.com".port`))
+	hosts := &appendValue{}
+	fs.Var(hosts, "hosts", "", fs.Cfg("servers.*.host"))
+	ports := &replaceValue{}
+	fs.Var(ports, "ports", "", fs.Cfg("servers[*].port"))
+
+	if *first != "a" || *port != 443 {
+		t.Errorf("expected the indexed and quoted notations to bind, got first=%v port=%v", *first, *port)
+	}
+	if hosts.String() != "a,b" || ports.String() != "1,2" {
+		t.Errorf("expected the wildcards to bind all the values, got hosts=%v ports=%v", hosts, ports)
+	}
+	if fs.CfgSource(`"example.com".port`) != paths[0] {
+		t.Errorf("expected the source of the quoted notation, got %q", fs.CfgSource(`"example.com".port`))
+	}
+
+	err = fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ports.String() != "1,2" {
+		t.Errorf("expected the values of a SliceValue to be replaced while loading again, got %v", ports)
+	}
+}
+
+func TestFlagSet_CfgNotationKeepsArrays(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.LoadCfgBytes([]byte(`{"servers":["a","b"]}`), "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the default of the flag can't be written under an array
+	fs.String("name", "default", "", fs.Cfg("servers.name"))
+	if fs.GetString("servers") != `["a","b"]` {
+		t.Errorf("expected the array to be kept, got %v", fs.GetString("servers"))
+	}
+	err = fs.WriteCfg(&strings.Builder{}, "json", CfgDumpAll)
+	if err == nil || !strings.Contains(err.Error(), "servers.name") {
+		t.Errorf("expected error for the value which can't be written, got %v", err)
+	}
+}
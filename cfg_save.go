@@ -0,0 +1,177 @@
+package flag
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// CfgDump decides what SaveCfg and WriteCfg write
+type CfgDump int
+
+const (
+	// CfgDumpAll writes the loaded cfg along with the values of all the flags bound to a cfg, including the defaults
+	CfgDumpAll CfgDump = iota
+	// CfgDumpChanged writes the values loaded from the cfg files and the values of the flags bound to a cfg
+	// which are set from the env, cfg or args, leaving out the defaults
+	CfgDumpChanged
+)
+
+// writes the effective cfg of the default flagset to w in the format like "yaml"
+// https://github.com/ondbyte/turbo_flag#saving-the-configuration
+func WriteCfg(w io.Writer, format string, dump CfgDump) error {
+	return CommandLine.WriteCfg(w, format, dump)
+}
+
+// writes the effective cfg to w in the format like "yaml", the one set using SetCfgFormat is used
+// when format is empty. the effective cfg is the loaded cfg with the values of the flags bound to a
+// cfg in this command and its sub commands, as they are set from the defaults, env, cfg or args.
+// the flags of a sub command which didn't run are only known when they are declared using SubCmdFlags.
+// https://github.com/ondbyte/turbo_flag#saving-the-configuration
+func (fs *FlagSet) WriteCfg(w io.Writer, format string, dump CfgDump) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.WriteCfg(w, format, dump)
+	}
+	if format == "" {
+		format = fs.cfgFormatExt
+	}
+	if format == "" {
+		return fmt.Errorf("format is empty while writing config, pass one or set it using SetCfgFormat")
+	}
+	cfgFormat, err := fs.formatNamed(format)
+	if err != nil {
+		return err
+	}
+	return fs.writeCfg(w, cfgFormat, dump)
+}
+
+// saves the effective cfg of the default flagset to the file at path
+// https://github.com/ondbyte/turbo_flag#saving-the-configuration
+func SaveCfg(path string, dump CfgDump) error {
+	return CommandLine.SaveCfg(path, dump)
+}
+
+// saves the effective cfg to the file at path like WriteCfg, the format is picked like LoadCfg
+// and "-" writes it to stdout. use it to write a starter file like `tool config init`.
+// https://github.com/ondbyte/turbo_flag#saving-the-configuration
+func (fs *FlagSet) SaveCfg(path string, dump CfgDump) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.SaveCfg(path, dump)
+	}
+	format, err := fs.formatOf(path)
+	if err != nil {
+		return err
+	}
+	if path == "-" {
+		return fs.writeCfg(os.Stdout, format, dump)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create config file at %v : %v", path, err)
+	}
+	err = fs.writeCfg(file, format, dump)
+	if closeErr := file.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("failed to save config file at %v : %v", path, closeErr)
+	}
+	return err
+}
+
+func (fs *FlagSet) writeCfg(w io.Writer, format CfgFormat, dump CfgDump) error {
+	var cfg map[string]interface{}
+	var err error
+	// the flags and the cfg don't change while they are read
+	fs.View(func() {
+		cfg, err = fs.effectiveCfg(dump)
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write config : %v", err)
+	}
+	content, err := format.Encode(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to write config : %v", err)
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+// effectiveCfg returns a copy of the cfg with the values of the flags bound to a cfg in the command tree,
+// call it inside View
+func (fs *FlagSet) effectiveCfg(dump CfgDump) (map[string]interface{}, error) {
+	cfg := make(map[string]interface{})
+	if dump == CfgDumpAll {
+		// copies the maps and the arrays so setting the values of the flags doesn't change the cfg
+		cfg = copyCfgValue(fs.cfg).(map[string]interface{})
+	} else {
+		notations := make([]string, 0, len(fs.cfgSources))
+		for notation := range fs.cfgSources {
+			notations = append(notations, notation)
+		}
+		sort.Strings(notations)
+		for _, notation := range notations {
+			if value, ok := cfgValue(fs.cfg, notation); ok {
+				cfg, _ = setValueByDotNotation(cfg, notation, copyCfgValue(value))
+			}
+		}
+	}
+	var flags []*Flag
+	fs.visitCfgFlags(func(flag *Flag) {
+		if dump == CfgDumpAll || flag.source != fromDefault {
+			flags = append(flags, flag)
+		}
+	})
+	// the flags set from a source with a higher precedence win when they are bound to the same cfg
+	sort.SliceStable(flags, func(i, j int) bool {
+		return flags[i].source < flags[j].source
+	})
+	for _, flag := range flags {
+		for _, notation := range sortedKeys(flag.cfgs) {
+			if hasWildcard(notation) {
+				continue
+			}
+			var err error
+			cfg, err = setValueByDotNotation(cfg, notation, cfgFlagValue(flag))
+			if err != nil {
+				return nil, fmt.Errorf("unable to write the value of flag %v to %v : %v", flag.Name, notation, err)
+			}
+		}
+	}
+	return cfg, nil
+}
+
+// visitCfgFlags calls fn for the flags bound to a cfg in this command and its sub commands,
+// the sub commands which didn't run only have the flags declared with SubCmdFlags
+func (fs *FlagSet) visitCfgFlags(fn func(flag *Flag)) {
+	for _, flag := range sortFlags(fs.formal) {
+		if flag.aliasFor == "" && len(flag.cfgs) > 0 {
+			fn(flag)
+		}
+	}
+	for _, name := range subCmdNames(fs) {
+		fs.SubCmds[name].fs.visitCfgFlags(fn)
+	}
+}
+
+// cfgValue returns the value at the dot notation in cfg as it is
+func cfgValue(cfg map[string]interface{}, notation string) (interface{}, bool) {
+	segments, err := parseNotation(notation)
+	if err != nil {
+		return nil, false
+	}
+	values := lookupNotation(cfg, segments)
+	if len(values) != 1 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+// cfgFlagValue returns the value of the flag typed as it is written to a cfg
+func cfgFlagValue(flag *Flag) interface{} {
+	if getter, ok := flag.Value.(Getter); ok {
+		switch value := getter.Get().(type) {
+		case bool, int, int64, uint, uint64, float64, string:
+			return value
+		}
+	}
+	return flag.Value.String()
+}
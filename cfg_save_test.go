@@ -0,0 +1,107 @@
+package flag_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_WriteCfg(t *testing.T) {
+	paths := writeCfgFiles(t, [2]string{"tool.yaml", "server:\n  host: from-cfg\nextra: kept\n"})
+	t.Setenv("TOOL_DEBUG", "true")
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.String("host", "localhost", "", fs.Cfg("server.host"))
+	fs.Int("port", 8080, "", fs.Cfg("server.port"))
+	fs.Bool("debug", false, "", fs.Env("TOOL_DEBUG"), fs.Cfg("debug"))
+	fs.String("name", "tool", "", fs.Cfg("name"))
+	fs.SubCmdFs("serve", "", func(fs *FlagSet, args []string) {
+		t.Fatal("serve should not run while writing the cfg")
+	})
+	fs.SubCmdFlags("serve", func(cmd CMD) {
+		cmd.Int("workers", 4, "", cmd.Cfg("serve.workers"))
+	})
+	err = fs.Parse([]string{"--name", "x"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	all := &bytes.Buffer{}
+	err = fs.WriteCfg(all, "yaml", CfgDumpAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `debug: true
+extra: kept
+name: x
+serve:
+  workers: 4
+server:
+  host: from-cfg
+  port: 8080
+`
+	if all.String() != want {
+		t.Errorf("expected the effective cfg\n%vgot\n%v", want, all.String())
+	}
+
+	changed := &bytes.Buffer{}
+	err = fs.WriteCfg(changed, "json", CfgDumpChanged)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want = `{"debug":true,"extra":"kept","name":"x","server":{"host":"from-cfg"}}`
+	if changed.String() != want {
+		t.Errorf("expected the changed cfg\n%v\ngot\n%v", want, changed.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "saved.toml")
+	err = fs.SaveCfg(path, CfgDumpAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := NewFlagSet("tool", ContinueOnError)
+	err = saved.LoadCfg(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := saved.Int("port", 0, "", saved.Cfg("server.port"))
+	if *port != 8080 {
+		t.Errorf("expected the saved cfg to load back, got port %v", *port)
+	}
+
+	err = fs.WriteCfg(&bytes.Buffer{}, "", CfgDumpAll)
+	if err == nil {
+		t.Error("expected error without a format")
+	}
+}
+
+func TestFlagSet_WriteCfgKeepsCfg(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.LoadCfgBytes([]byte("servers:\n  - host: cfg\n"), "yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.String("host", "", "", fs.Cfg("servers.0.host"))
+	err = fs.Parse([]string{"--host", "args"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dump := range []CfgDump{CfgDumpAll, CfgDumpChanged} {
+		b := &bytes.Buffer{}
+		err = fs.WriteCfg(b, "json", dump)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if b.String() != `{"servers":[{"host":"args"}]}` {
+			t.Errorf("expected the value of the flag in the dump, got %v", b.String())
+		}
+		if fs.GetString("servers.0.host") != "cfg" {
+			t.Errorf("expected the dump to leave the cfg unchanged, got %v", fs.GetString("servers.0.host"))
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package flag
+
+import (
+	"context"
+	"encoding"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// watches the cfg files loaded to the default flagset and reloads them when they change
+// https://github.com/ondbyte/turbo_flag#hot-reloading-the-configuration
+func WatchCfg(ctx context.Context, onChange func(changed []string, err error)) error {
+	return CommandLine.WatchCfg(ctx, onChange)
+}
+
+// watches the cfg files loaded using LoadCfg, LoadCfgs, MergeCfg or FindCfg by polling them until ctx is done.
+// when one of them changes all of them are read again and the flags bound to a cfg are set again, unless they
+// are set from the arguments. onChange is called after every reload with the flags whose value changed, the
+// ones of the sub commands like "serve workers", or with the error which prevented the reload, the previous
+// values are kept then. every value of the new cfg is checked before any flag is changed, except the ones
+// of the flags defined with Func which are only checked while they are set. the values are changed holding
+// a lock, read them inside View to not see them half changed.
+// https://github.com/ondbyte/turbo_flag#hot-reloading-the-configuration
+func (fs *FlagSet) WatchCfg(ctx context.Context, onChange func(changed []string, err error)) error {
+	if fs.parentCmd != nil {
+		return fs.parentCmd.WatchCfg(ctx, onChange)
+	}
+	fs.cfgMu.RLock()
+	paths := fs.cfgPaths
+	fs.cfgMu.RUnlock()
+	if len(paths) == 0 {
+		return fs.msgErr(MsgCfgNoWatch)
+	}
+	for _, path := range paths {
+		if path == "-" {
+			return fs.msgErr(MsgCfgStdinWatch)
+		}
+	}
+	interval := fs.watchInterval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	states := cfgFileStates(paths)
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			fs.cfgMu.RLock()
+			paths := fs.cfgPaths
+			fs.cfgMu.RUnlock()
+			current := cfgFileStates(paths)
+			if current == states {
+				continue
+			}
+			states = current
+			changed, err := fs.reloadCfg(paths)
+			onChange(changed, err)
+		}
+	}()
+	return nil
+}
+
+// sets how often WatchCfg polls the cfg files of the default flagset
+// https://github.com/ondbyte/turbo_flag#hot-reloading-the-configuration
+func SetWatchInterval(interval time.Duration) {
+	CommandLine.SetWatchInterval(interval)
+}
+
+// sets how often WatchCfg polls the cfg files, every second by default
+// https://github.com/ondbyte/turbo_flag#hot-reloading-the-configuration
+func (fs *FlagSet) SetWatchInterval(interval time.Duration) {
+	if fs.parentCmd != nil {
+		fs.parentCmd.SetWatchInterval(interval)
+		return
+	}
+	fs.watchInterval = interval
+}
+
+// runs fn while the cfg of the default flagset is not being reloaded
+// https://github.com/ondbyte/turbo_flag#hot-reloading-the-configuration
+func View(fn func()) {
+	CommandLine.View(fn)
+}
+
+// runs fn while the cfg and the flags bound to it are not being changed by WatchCfg or the Load methods,
+// read the values of the flags inside fn to see all of them from the same cfg. the Get methods take the
+// same lock so don't call them inside fn, read them from a copy made using Sub("") instead.
+// https://github.com/ondbyte/turbo_flag#hot-reloading-the-configuration
+func (fs *FlagSet) View(fn func()) {
+	if fs.parentCmd != nil {
+		fs.parentCmd.View(fn)
+		return
+	}
+	fs.cfgMu.RLock()
+	defer fs.cfgMu.RUnlock()
+	fn()
+}
+
+// cfgFileStates returns the modification time and size of the files at paths, or the error while checking them
+func cfgFileStates(paths []string) string {
+	b := new(strings.Builder)
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			fmt.Fprintf(b, "%v:%v\n", path, err)
+			continue
+		}
+		fmt.Fprintf(b, "%v:%v:%v\n", path, info.ModTime().UnixNano(), info.Size())
+	}
+	return b.String()
+}
+
+// reloadCfg reads the cfg files at paths again and sets the flags bound to them, it returns the flags
+// whose value changed
+func (fs *FlagSet) reloadCfg(paths []string) ([]string, error) {
+	cfg, sources, err := fs.readCfgFiles(paths)
+	if err != nil {
+		return nil, fs.msgErr(MsgCfgReloadFailed, err)
+	}
+	fs.cfgMu.Lock()
+	defer fs.cfgMu.Unlock()
+	// nothing is changed unless every value of the new cfg is valid for its flag
+	err = checkCfgFlags(fs, cfg)
+	if err != nil {
+		return nil, fs.msgErr(MsgCfgReloadFailed, err)
+	}
+	before := make(map[*Flag]string)
+	visitBoundFlags(fs, func(flag *Flag) {
+		before[flag] = flag.Value.String()
+	})
+	fs.cfg = cfg
+	fs.cfgSources = sources
+	resetRemovedCfgFlags(fs)
+	err = bindCfgTree(fs)
+	if err != nil {
+		return nil, fs.msgErr(MsgCfgReloadFailed, err)
+	}
+	var changed []string
+	visitBoundFlags(fs, func(flag *Flag) {
+		if flag.Value.String() != before[flag] {
+			path := append(flag.flagSet.commandPath()[1:], flag.Name)
+			changed = append(changed, strings.Join(path, " "))
+		}
+	})
+	sort.Strings(changed)
+	return changed, nil
+}
+
+// checkCfgFlags sets the values of cfg to a copy of every flag bound to it in fs and the sub commands it
+// holds, it returns the first value which is invalid for its flag
+func checkCfgFlags(fs *FlagSet, cfg map[string]interface{}) error {
+	var err error
+	visitBoundFlags(fs, func(flag *Flag) {
+		if err != nil || flag.source == fromArgs {
+			return
+		}
+		value, ok := emptyValue(flag.Value)
+		if !ok {
+			return
+		}
+		scratch := *flag
+		scratch.Value = value
+		for notation := range flag.cfgs {
+			_, err = setFromCfg(&scratch, cfg, notation)
+			if err != nil {
+				return
+			}
+		}
+	})
+	return err
+}
+
+// emptyValue returns a new value of the type of v, the values which are not a pointer to their value like
+// the ones defined with Func can't be copied and are only checked while they are set
+func emptyValue(v Value) (Value, bool) {
+	if text, ok := v.(textValue); ok {
+		p, ok := reflect.New(reflect.TypeOf(text.p).Elem()).Interface().(encoding.TextUnmarshaler)
+		return textValue{p}, ok
+	}
+	t := reflect.TypeOf(v)
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, false
+	}
+	value, ok := reflect.New(t.Elem()).Interface().(Value)
+	return value, ok
+}
+
+// visitBoundFlags calls fn for the flags bound to a cfg in fs and the sub commands it holds
+func visitBoundFlags(fs *FlagSet, fn func(flag *Flag)) {
+	for _, flag := range fs.formal {
+		if flag.aliasFor == "" && len(flag.cfgs) > 0 {
+			fn(flag)
+		}
+	}
+	for _, sc := range fs.SubCmds {
+		visitBoundFlags(sc.fs, fn)
+	}
+}
+
+// resetRemovedCfgFlags sets the flags whose value came from a cfg which is not in the cfg anymore back to
+// their default, or the value of their env
+func resetRemovedCfgFlags(fs *FlagSet) {
+	visitBoundFlags(fs, func(flag *Flag) {
+		if flag.source != fromCfg {
+			return
+		}
+		for notation := range flag.cfgs {
+			if val, err := getValueByDotNotation(fs.cfg, notation); err == nil && val != "" {
+				return
+			}
+		}
+		if slice, ok := flag.Value.(SliceValue); ok {
+			// Set appends to the values of a slice
+			err := slice.Replace(nil)
+			if err != nil {
+				return
+			}
+		}
+		if flag.Value.String() != flag.DefValue {
+			err := flag.Value.Set(flag.DefValue)
+			if err != nil {
+				return
+			}
+		}
+		flag.source = fromDefault
+		flag.flagSet.bindEnv(flag, keys(flag.envs)...)
+	})
+}
@@ -0,0 +1,226 @@
+package flag_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_WatchCfg(t *testing.T) {
+	paths := writeCfgFiles(t, [2]string{"tool.yaml", "host: a\nport: 1\nserve:\n  name: cfg\n  workers: 1\n"})
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetWatchInterval(5 * time.Millisecond)
+	err := fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	host := fs.String("host", "localhost", "", fs.Cfg("host"))
+	port := fs.Int("port", 0, "", fs.Cfg("port"))
+	var workers *int
+	var name *string
+	fs.SubCmdFs("serve", "", func(fs *FlagSet, args []string) {
+		workers = fs.Int("workers", 0, "", fs.Cfg("serve.workers"))
+		name = fs.String("name", "", "", fs.Cfg("serve.name"))
+		err := fs.Parse(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	err = fs.Parse([]string{"serve", "--name", "args"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		changed []string
+		err     error
+	}
+	changes := make(chan change)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = fs.WatchCfg(ctx, func(changed []string, err error) {
+		changes <- change{changed, err}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewrite := func(content string) change {
+		t.Helper()
+		// renamed so the watcher never sees the file half written
+		err := os.WriteFile(paths[0]+".tmp", []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Rename(paths[0]+".tmp", paths[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case c := <-changes:
+			return c
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the change to be reported")
+		}
+		return change{}
+	}
+
+	c := rewrite("host: a\nport: 22\nserve:\n  name: changed\n  workers: 8\n")
+	if c.err != nil || !reflect.DeepEqual(c.changed, []string{"port", "serve workers"}) {
+		t.Fatalf("expected port and serve workers to change, got %v : %v", c.changed, c.err)
+	}
+	fs.View(func() {
+		if *port != 22 || *workers != 8 || *name != "args" {
+			t.Errorf("expected the reloaded values and the args to win, got port=%v workers=%v name=%v", *port, *workers, *name)
+		}
+	})
+
+	c = rewrite("host: a\nport: not a number\n")
+	if c.err == nil {
+		t.Fatal("expected the invalid value to be reported")
+	}
+	fs.View(func() {
+		if *port != 22 || *host != "a" {
+			t.Errorf("expected the previous values to be kept, got port=%v host=%v", *port, *host)
+		}
+	})
+
+	c = rewrite("port: 22\nserve:\n  workers: 8\n")
+	if c.err != nil || !reflect.DeepEqual(c.changed, []string{"host"}) {
+		t.Fatalf("expected host to change, got %v : %v", c.changed, c.err)
+	}
+	fs.View(func() {
+		if *host != "localhost" {
+			t.Errorf("expected the removed value to fall back to the default, got %v", *host)
+		}
+	})
+
+	err = NewFlagSet("tool", ContinueOnError).WatchCfg(ctx, func([]string, error) {})
+	if err == nil {
+		t.Error("expected error when no config is loaded")
+	}
+}
+
+func TestFlagSet_WatchCfgSlice(t *testing.T) {
+	paths := writeCfgFiles(t, [2]string{"tool.yaml", "port: 1\ntags:\n  - a\n  - b\n"})
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetWatchInterval(5 * time.Millisecond)
+	err := fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	port := fs.Int("port", 0, "", fs.Cfg("port"))
+	tags := &replaceValue{}
+	fs.Var(tags, "tags", "", fs.Cfg("tags.*"))
+
+	errs := make(chan error)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = fs.WatchCfg(ctx, func(changed []string, err error) {
+		errs <- err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rewrite := func(content string) error {
+		t.Helper()
+		err := os.WriteFile(paths[0]+".tmp", []byte(content), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.Rename(paths[0]+".tmp", paths[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case err := <-errs:
+			return err
+		case <-time.After(5 * time.Second):
+			t.Fatal("expected the change to be reported")
+		}
+		return nil
+	}
+
+	err = rewrite("port: not a number\ntags:\n  - c\n")
+	if err == nil {
+		t.Fatal("expected the invalid value to be reported")
+	}
+	fs.View(func() {
+		if tags.String() != "a,b" || *port != 1 {
+			t.Errorf("expected nothing to change, got tags=%v port=%v", tags, *port)
+		}
+	})
+
+	err = rewrite("port: 2\ntags:\n  - c\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.View(func() {
+		if tags.String() != "c" || *port != 2 {
+			t.Errorf("expected the values to be replaced, got tags=%v port=%v", tags, *port)
+		}
+	})
+
+	err = rewrite("port: 2\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.View(func() {
+		if tags.String() != "" {
+			t.Errorf("expected the removed values to be dropped, got %v", tags)
+		}
+	})
+}
+
+func TestFlagSet_WatchCfgDeprecated(t *testing.T) {
+	paths := writeCfgFiles(t, [2]string{"tool.yaml", "addr: a\nport: 1\n"})
+	warnings := &bytes.Buffer{}
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetWarningOutput(warnings)
+	fs.SetWatchInterval(5 * time.Millisecond)
+	err := fs.LoadCfg(paths[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.String("addr", "", "", fs.Deprecated("use host", "host"), fs.Cfg("addr"))
+	host := fs.String("host", "localhost", "")
+	fs.Int("port", 0, "", fs.Cfg("port"))
+	err = fs.Parse([]string{"--host", "args"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes := make(chan []string)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	err = fs.WatchCfg(ctx, func(changed []string, err error) {
+		changes <- changed
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	warnings.Reset()
+	err = os.WriteFile(paths[0]+".tmp", []byte("addr: a\nport: 22\n"), 0644)
+	if err == nil {
+		err = os.Rename(paths[0]+".tmp", paths[0])
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case changed := <-changes:
+		if !reflect.DeepEqual(changed, []string{"port"}) {
+			t.Fatalf("expected port to change, got %v", changed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the change to be reported")
+	}
+	fs.View(func() {
+		if warnings.Len() != 0 || *host != "args" {
+			t.Errorf("expected no warnings for the unchanged deprecated flag and host from the args, got %q host=%v", warnings.String(), *host)
+		}
+	})
+}
@@ -0,0 +1,154 @@
+package flag
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+)
+
+// ColorMode decides whether the usage, errors and warnings are styled with ANSI escape codes
+type ColorMode int
+
+const (
+	// ColorAuto styles the output when it is a terminal and the NO_COLOR env is not set,
+	// it is the default and means the mode of the parent command for sub commands.
+	ColorAuto ColorMode = iota
+	// ColorAlways always styles the output
+	ColorAlways
+	// ColorNever never styles the output
+	ColorNever
+)
+
+// Theme holds the SGR parameters the parts of the output are styled with, like "1" for bold
+// or "1;31" for bold red, an empty parameter leaves the part unstyled.
+type Theme struct {
+	Heading string // section headings like "Flags:"
+	Command string // names of the sub commands
+	Flag    string // names of the flags
+	Default string // default values of the flags
+	Error   string // errors printed while exiting
+	Warning string // warnings like the ones about deprecated flags
+}
+
+// DefaultTheme is the theme used unless it is replaced using SetTheme
+var DefaultTheme = Theme{
+	Heading: "1",
+	Command: "36",
+	Flag:    "36",
+	Default: "2",
+	Error:   "1;31",
+	Warning: "33",
+}
+
+// Style styles text with a theme, the zero Style leaves the text as it is.
+// UsageData has the Style of the command, templates can use it like {{.Style.Heading "Flags:"}}
+type Style struct {
+	theme *Theme
+}
+
+func (s Style) paint(sgr string, text string) string {
+	if s.theme == nil || sgr == "" || text == "" {
+		return text
+	}
+	return "\x1b[" + sgr + "m" + text + "\x1b[0m"
+}
+
+// Heading, Command, Flag, Default, Error and Warning style text as the respective part of the theme
+func (s Style) Heading(text string) string { return s.paint(s.sgr().Heading, text) }
+func (s Style) Command(text string) string { return s.paint(s.sgr().Command, text) }
+func (s Style) Flag(text string) string    { return s.paint(s.sgr().Flag, text) }
+func (s Style) Default(text string) string { return s.paint(s.sgr().Default, text) }
+func (s Style) Error(text string) string   { return s.paint(s.sgr().Error, text) }
+func (s Style) Warning(text string) string { return s.paint(s.sgr().Warning, text) }
+
+// FlagUsage is flagUsage with the default value styled
+func (s Style) FlagUsage(long bool, flag UsageFlag) string {
+	return styledFlagUsage(s, long, flag)
+}
+
+func (s Style) sgr() Theme {
+	if s.theme == nil {
+		return Theme{}
+	}
+	return *s.theme
+}
+
+var ansiEscape = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// visibleLen returns the length of s as shown in a terminal, ignoring the ANSI escape codes
+func visibleLen(s string) int {
+	return len(ansiEscape.ReplaceAllString(s, ""))
+}
+
+// sets whether the output of the default flagset is styled
+// https://github.com/ondbyte/turbo_flag#colors
+func SetColor(mode ColorMode) {
+	CommandLine.SetColor(mode)
+}
+
+// sets whether the usage, errors and warnings of this command and its sub commands are styled,
+// by default they are styled when written to a terminal and the NO_COLOR env is not set.
+// https://github.com/ondbyte/turbo_flag#colors
+func (fs *FlagSet) SetColor(mode ColorMode) {
+	fs.colorMode = mode
+}
+
+// replaces the theme the output of the default flagset is styled with
+// https://github.com/ondbyte/turbo_flag#colors
+func SetTheme(theme Theme) {
+	CommandLine.SetTheme(theme)
+}
+
+// replaces the theme the usage, errors and warnings of this command and its sub commands are styled with
+// https://github.com/ondbyte/turbo_flag#colors
+func (fs *FlagSet) SetTheme(theme Theme) {
+	fs.theme = &theme
+}
+
+// style returns the Style to write to w with, the zero Style when the output should not be styled.
+// w is nil when it is not known where the output is written, it is styled only with ColorAlways then
+func (fs *FlagSet) style(w io.Writer) Style {
+	mode := ColorAuto
+	theme := &DefaultTheme
+	for cmd := fs; cmd != nil; cmd = cmd.parentCmd {
+		if mode == ColorAuto {
+			mode = cmd.colorMode
+		}
+		if cmd.theme != nil && theme == &DefaultTheme {
+			theme = cmd.theme
+		}
+	}
+	switch mode {
+	case ColorAlways:
+		return Style{theme: theme}
+	case ColorNever:
+		return Style{}
+	}
+	if os.Getenv("NO_COLOR") != "" || !isTerminal(w) {
+		return Style{}
+	}
+	return Style{theme: theme}
+}
+
+// isTerminal reports whether w is a character device like a terminal
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// printError prints err to the output of the command styled as an error
+func (fs *FlagSet) printError(err error) {
+	w := fs.Output()
+	fmt.Fprintln(w, fs.style(w).Error(fs.msg(MsgError, err)))
+}
+
+// printWarning prints the warning to the warning output styled as a warning
+func (fs *FlagSet) printWarning(warning string) {
+	w := fs.warningOutput()
+	fmt.Fprintln(w, fs.style(w).Warning(fs.msg(MsgWarning, warning)))
+}
@@ -0,0 +1,113 @@
+package flag_test
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_SetColor(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetUsageWidth(200)
+	fs.String("mode", "a", "the mode")
+	fs.SubCmdFs("commit", "commits the changes", func(fs *FlagSet, args []string) {})
+	// not a terminal, so no colors
+	usage, err := fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.ContainsRune([]byte(usage), '\x1b') {
+		t.Fatalf("expected no escape codes while not writing to a terminal, got %q", usage)
+	}
+	fs.SetColor(ColorAlways)
+	fs.SetTheme(Theme{Heading: "1", Flag: "32", Default: "2"})
+	usage, err = fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b[1musage:\x1b[0m\n" +
+		"  tool [<flags>]\n" +
+		"  or\n" +
+		"  tool [<sub-command>]\n" +
+		"\n" +
+		"\x1b[1mAvailable sub commands:\x1b[0m\n" +
+		"  commit  commits the changes\n" +
+		"\n" +
+		"\x1b[1mFlags:\x1b[0m\n" +
+		"  \x1b[32m--mode string\x1b[0m  the mode (defaults to \x1b[2m\"a\"\x1b[0m)\n" +
+		"\n" +
+		"Use \"tool [command] --help\" for more information about a command.\n"
+	if usage != want {
+		t.Errorf("GetDefaultUsage() =\n%q\nwant\n%q", usage, want)
+	}
+}
+
+func TestFlagSet_SetColorWarnings(t *testing.T) {
+	warnings := &bytes.Buffer{}
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetWarningOutput(warnings)
+	fs.SetColor(ColorAlways)
+	fs.SubCmdFs("run", "", func(fs *FlagSet, args []string) {
+		fs.Bool("fast", false, "", fs.Deprecated("it is always fast", ""))
+		fs.Parse(args)
+	})
+	err := fs.Parse([]string{"run", "--fast"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "\x1b[33mwarning: flag -fast is deprecated, it is always fast\x1b[0m\n"
+	if warnings.String() != want {
+		t.Errorf("expected the warning %q, got %q", want, warnings.String())
+	}
+}
+
+func TestFlagSet_PrintErrorToOutput(t *testing.T) {
+	out := &bytes.Buffer{}
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetOutput(out)
+	fs.EnableCompletion()
+	err := fs.Parse([]string{"completion"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(out.Bytes(), []byte("completion needs one argument")) || bytes.ContainsRune(out.Bytes(), '\x1b') {
+		t.Errorf("expected the error without colors in the output, got %q", out.String())
+	}
+}
+
+func TestFlagSet_WriteUsage(t *testing.T) {
+	out := &bytes.Buffer{}
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetOutput(out)
+	fs.String("mode", "a", "the mode")
+	var sub io.Writer
+	fs.SubCmdFs("commit", "", func(fs *FlagSet, args []string) {
+		sub = fs.Output()
+	})
+	err := fs.Parse([]string{"commit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub != out {
+		t.Error("expected the sub command to write to the output of its parent")
+	}
+	b := &bytes.Buffer{}
+	err = fs.WriteUsage(b, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(b.Bytes(), []byte("--mode")) || bytes.ContainsRune(b.Bytes(), '\x1b') {
+		t.Errorf("expected the usage without colors while not writing to a terminal, got %q", b.String())
+	}
+	fs.SetColor(ColorAlways)
+	b.Reset()
+	err = fs.WriteUsage(b, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.ContainsRune(b.Bytes(), '\x1b') {
+		t.Errorf("expected the usage with colors, got %q", b.String())
+	}
+}
@@ -0,0 +1,441 @@
+package flag
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// shells GenCompletion can write a completion script for
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// compCmd is a command in the tree the completion scripts are generated from
+type compCmd struct {
+	id       string // identifier safe to use in shell function names and case labels
+	name     string
+	usage    string
+	flags    []compFlag
+	children []*compCmd
+}
+
+type compFlag struct {
+	names      []string // name of the flag followed by its aliases
+	usage      string
+	takesValue bool
+	enums      []string
+}
+
+func newCompCmd(fs *FlagSet, id string) *compCmd {
+	cmd := &compCmd{id: id, name: fs.name, usage: fs.usg}
+	for _, flag := range sortFlags(fs.formal) {
+		if flag.aliasFor != "" || flag.hidden {
+			continue
+		}
+		cmd.flags = append(cmd.flags, compFlag{
+			names:      append([]string{flag.Name}, sortedKeys(flag.alias)...),
+			usage:      flag.Usage,
+			takesValue: flagTakesValue(flag),
+			enums:      sortedKeys(flag.enums),
+		})
+	}
+	for _, sc := range fs.visibleSubCmds() {
+		cmd.children = append(cmd.children, newCompCmd(sc.fs, id+"_"+shellIdent(sc.fs.name)))
+	}
+	return cmd
+}
+
+// walk calls fn for cmd and all of its children, parents first
+func (c *compCmd) walk(fn func(c *compCmd)) {
+	fn(c)
+	for _, child := range c.children {
+		child.walk(fn)
+	}
+}
+
+func shellIdent(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) {
+			return r
+		}
+		return '_'
+	}, s)
+}
+
+// flagWord returns how a flag is offered as a completion, -n for single letter names and --name for the others
+func flagWord(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+	return "--" + name
+}
+
+// flagWords returns all the ways a flag can be typed, the package accepts both -name and --name
+func (f compFlag) flagWords() []string {
+	var words []string
+	for _, name := range f.names {
+		words = append(words, "-"+name, "--"+name)
+	}
+	return words
+}
+
+func (c *compCmd) flagWords() []string {
+	var words []string
+	for _, f := range c.flags {
+		for _, name := range f.names {
+			words = append(words, flagWord(name))
+		}
+	}
+	return words
+}
+
+func (c *compCmd) subNames() []string {
+	var names []string
+	for _, child := range c.children {
+		names = append(names, child.name)
+	}
+	return names
+}
+
+// singleQuote quotes s for the posix shells and fish
+func singleQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// psQuote quotes s for powershell
+func psQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// GenCompletion writes the completion script for the shell to w, the script completes the
+// sub commands, flags, aliases and enum values of the flags of this command and its sub commands.
+// supported shells are bash, zsh, fish and powershell.
+// https://github.com/ondbyte/turbo_flag#shell-completion
+func GenCompletion(w io.Writer, shell string) error {
+	return CommandLine.GenCompletion(w, shell)
+}
+
+// GenCompletion writes the completion script for the shell to w, the script completes the
+// sub commands, flags, aliases and enum values of the flags of this command and its sub commands.
+// supported shells are bash, zsh, fish and powershell. the callbacks of the sub commands are not called,
+// the flags and sub commands of a sub command are completed only when they are declared using SubCmdFlags.
+// https://github.com/ondbyte/turbo_flag#shell-completion
+func (fs *FlagSet) GenCompletion(w io.Writer, shell string) error {
+	program := filepath.Base(fs.name)
+	root := newCompCmd(fs, shellIdent(program))
+	root.name = program
+	var script string
+	switch shell {
+	case "bash":
+		script = genBashCompletion(root)
+	case "zsh":
+		script = genZshCompletion(root)
+	case "fish":
+		script = genFishCompletion(root)
+	case "powershell":
+		script = genPowerShellCompletion(root)
+	default:
+		return fs.msgErr(MsgUnsupportedShell, shell, strings.Join(completionShells, ", "))
+	}
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// adds a hidden "completion <shell>" sub command to the default flagset which prints the completion script
+// https://github.com/ondbyte/turbo_flag#shell-completion
+func EnableCompletion() {
+	CommandLine.EnableCompletion()
+}
+
+// adds a hidden "completion <shell>" sub command which prints the completion script for the shell to stdout,
+// for example "source <(yourProgram completion bash)" enables completions in bash. it panics when the
+// completion is already enabled, it can't be used along with EnableDynamicCompletion.
+// https://github.com/ondbyte/turbo_flag#shell-completion
+func (fs *FlagSet) EnableCompletion() {
+	fs.addCompletionCmd(fs.GenCompletion)
+}
+
+// checkCompletionCmd panics when the "completion" sub command is already added, the last one would win otherwise
+func (fs *FlagSet) checkCompletionCmd() {
+	if _, ok := fs.SubCmds["completion"]; ok {
+		panic(fmt.Sprintf("%v already has a completion sub command, enable either the completion or the dynamic completion once", fs.name))
+	}
+}
+
+// addCompletionCmd adds the hidden "completion <shell>" sub command printing the script gen writes for the shell
+func (fs *FlagSet) addCompletionCmd(gen func(w io.Writer, shell string) error) {
+	fs.checkCompletionCmd()
+	fs.SubCmdFs("completion", "prints the shell completion script", func(sub *FlagSet, args []string) {
+		err := sub.Parse(args)
+		if err == nil && sub.NArg() != 1 {
+			err = fs.msgErr(MsgCompletionArgs, strings.Join(completionShells, ", "))
+		}
+		if err == nil {
+			err = gen(os.Stdout, sub.Arg(0))
+		}
+		if err != nil {
+			if fs.handleError(err) != nil {
+				fs.printError(err)
+			}
+		}
+	})
+	fs.SubCmds["completion"].hidden = true
+}
+
+// the scripts below walk the words typed so far to find the sub command being completed,
+// a word is a sub command only when it directly follows its parent command, like Parse expects.
+
+func writeTransitions(b *strings.Builder, root *compCmd, indent string, format string) {
+	root.walk(func(c *compCmd) {
+		for _, child := range c.children {
+			fmt.Fprintf(b, indent+format, c.id+"/"+child.name, child.id)
+		}
+	})
+}
+
+func genBashCompletion(root *compCmd) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# bash completion for %v, generated by github.com/ondbyte/turbo_flag\n", root.name)
+	fmt.Fprintf(b, "# load it with: source <(%v completion bash)\n\n", root.name)
+	fmt.Fprintf(b, "_%v() {\n", root.id)
+	b.WriteString("    local cur prev cmd next i\n")
+	b.WriteString("    cur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	b.WriteString("    prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(b, "    cmd=%v\n", root.id)
+	b.WriteString("    next=1\n")
+	b.WriteString("    for ((i = 1; i < COMP_CWORD; i++)); do\n")
+	b.WriteString("        [[ ${i} -eq ${next} ]] || continue\n")
+	b.WriteString("        case \"${cmd}/${COMP_WORDS[i]}\" in\n")
+	writeTransitions(b, root, "            ", "%v) cmd=%v; next=$((i + 1)) ;;\n")
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n")
+	b.WriteString("    case \"${cmd}\" in\n")
+	root.walk(func(c *compCmd) {
+		fmt.Fprintf(b, "        %v)\n", c.id)
+		b.WriteString("            case \"${prev}\" in\n")
+		for _, f := range c.flags {
+			if !f.takesValue {
+				continue
+			}
+			fmt.Fprintf(b, "                %v)\n", strings.Join(f.flagWords(), "|"))
+			if len(f.enums) > 0 {
+				fmt.Fprintf(b, "                    COMPREPLY=($(compgen -W %v -- \"${cur}\"))\n", singleQuote(strings.Join(f.enums, " ")))
+			} else {
+				b.WriteString("                    COMPREPLY=($(compgen -f -- \"${cur}\"))\n")
+			}
+			b.WriteString("                    return ;;\n")
+		}
+		b.WriteString("            esac\n")
+		flags := fmt.Sprintf("COMPREPLY=($(compgen -W %v -- \"${cur}\"))", singleQuote(strings.Join(c.flagWords(), " ")))
+		if len(c.children) > 0 {
+			b.WriteString("            if [[ \"${cur}\" == -* || ${COMP_CWORD} -ne ${next} ]]; then\n")
+			fmt.Fprintf(b, "                %v\n", flags)
+			b.WriteString("            else\n")
+			fmt.Fprintf(b, "                COMPREPLY=($(compgen -W %v -- \"${cur}\"))\n", singleQuote(strings.Join(c.subNames(), " ")))
+			b.WriteString("            fi\n")
+		} else {
+			fmt.Fprintf(b, "            %v\n", flags)
+		}
+		b.WriteString("            ;;\n")
+	})
+	b.WriteString("    esac\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(b, "complete -F _%v %v\n", root.id, root.name)
+	return b.String()
+}
+
+// zshDescribe formats name and usage as an entry for zsh's _describe
+func zshDescribe(name string, usage string) string {
+	name = strings.ReplaceAll(name, ":", `\:`)
+	usage = strings.Join(strings.Fields(usage), " ")
+	if usage == "" {
+		return singleQuote(name)
+	}
+	return singleQuote(name + ":" + usage)
+}
+
+func genZshCompletion(root *compCmd) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "#compdef %v\n", root.name)
+	fmt.Fprintf(b, "# zsh completion for %v, generated by github.com/ondbyte/turbo_flag\n", root.name)
+	fmt.Fprintf(b, "# load it with: source <(%v completion zsh)\n\n", root.name)
+	fmt.Fprintf(b, "_%v() {\n", root.id)
+	b.WriteString("    local cur=\"${words[CURRENT]}\" prev=\"${words[CURRENT-1]}\"\n")
+	fmt.Fprintf(b, "    local cmd=%v next=2 i\n", root.id)
+	b.WriteString("    local -a candidates\n")
+	b.WriteString("    for ((i = 2; i < CURRENT; i++)); do\n")
+	b.WriteString("        (( i == next )) || continue\n")
+	b.WriteString("        case \"${cmd}/${words[i]}\" in\n")
+	writeTransitions(b, root, "            ", "%v) cmd=%v; next=$((i + 1)) ;;\n")
+	b.WriteString("        esac\n")
+	b.WriteString("    done\n")
+	b.WriteString("    case \"${cmd}\" in\n")
+	root.walk(func(c *compCmd) {
+		fmt.Fprintf(b, "        %v)\n", c.id)
+		b.WriteString("            case \"${prev}\" in\n")
+		for _, f := range c.flags {
+			if !f.takesValue {
+				continue
+			}
+			fmt.Fprintf(b, "                %v)\n", strings.Join(f.flagWords(), "|"))
+			if len(f.enums) > 0 {
+				fmt.Fprintf(b, "                    compadd -- %v\n", strings.Join(quoteAll(f.enums, singleQuote), " "))
+			} else {
+				b.WriteString("                    _files\n")
+			}
+			b.WriteString("                    return ;;\n")
+		}
+		b.WriteString("            esac\n")
+		var flags []string
+		for _, f := range c.flags {
+			for _, name := range f.names {
+				flags = append(flags, zshDescribe(flagWord(name), f.usage))
+			}
+		}
+		flagsLine := fmt.Sprintf("candidates=(%v); _describe 'flag' candidates", strings.Join(flags, " "))
+		if len(c.children) > 0 {
+			var subs []string
+			for _, child := range c.children {
+				subs = append(subs, zshDescribe(child.name, child.usage))
+			}
+			b.WriteString("            if [[ \"${cur}\" == -* || ${CURRENT} -ne ${next} ]]; then\n")
+			fmt.Fprintf(b, "                %v\n", flagsLine)
+			b.WriteString("            else\n")
+			fmt.Fprintf(b, "                candidates=(%v); _describe 'command' candidates\n", strings.Join(subs, " "))
+			b.WriteString("            fi\n")
+		} else {
+			fmt.Fprintf(b, "            %v\n", flagsLine)
+		}
+		b.WriteString("            ;;\n")
+	})
+	b.WriteString("    esac\n")
+	b.WriteString("}\n\n")
+	fmt.Fprintf(b, "if [[ \"${funcstack[1]}\" == \"_%v\" ]]; then\n", root.id)
+	fmt.Fprintf(b, "    _%v \"$@\"\n", root.id)
+	b.WriteString("else\n")
+	fmt.Fprintf(b, "    compdef _%v %v\n", root.id, root.name)
+	b.WriteString("fi\n")
+	return b.String()
+}
+
+func quoteAll(s []string, quote func(string) string) []string {
+	quoted := make([]string, len(s))
+	for i, v := range s {
+		quoted[i] = quote(v)
+	}
+	return quoted
+}
+
+func genFishCompletion(root *compCmd) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# fish completion for %v, generated by github.com/ondbyte/turbo_flag\n", root.name)
+	fmt.Fprintf(b, "# load it with: %v completion fish | source\n\n", root.name)
+	b.WriteString("# succeeds when the command being completed is $argv[1], with a second argument\n")
+	b.WriteString("# only when the next word can be one of its sub commands\n")
+	fmt.Fprintf(b, "function __%v_is\n", root.id)
+	b.WriteString("    set -l tokens (commandline -opc)\n")
+	fmt.Fprintf(b, "    set -l cmd %v\n", root.id)
+	b.WriteString("    set -l next 2\n")
+	b.WriteString("    set -l i 2\n")
+	b.WriteString("    while test $i -le (count $tokens)\n")
+	b.WriteString("        if test $i -eq $next\n")
+	b.WriteString("            switch \"$cmd/$tokens[$i]\"\n")
+	writeTransitions(b, root, "                ", "case %v\n                    set cmd %v\n                    set next (math $i + 1)\n")
+	b.WriteString("            end\n")
+	b.WriteString("        end\n")
+	b.WriteString("        set i (math $i + 1)\n")
+	b.WriteString("    end\n")
+	b.WriteString("    test \"$cmd\" = \"$argv[1]\"; or return 1\n")
+	b.WriteString("    if set -q argv[2]\n")
+	b.WriteString("        test $i -eq $next\n")
+	b.WriteString("    end\n")
+	b.WriteString("end\n\n")
+	fmt.Fprintf(b, "complete -c %v -f\n", root.name)
+	root.walk(func(c *compCmd) {
+		for _, child := range c.children {
+			fmt.Fprintf(b, "complete -c %v -n '__%v_is %v sub' -a %v", root.name, root.id, c.id, singleQuote(child.name))
+			if child.usage != "" {
+				fmt.Fprintf(b, " -d %v", singleQuote(child.usage))
+			}
+			b.WriteString("\n")
+		}
+		for _, f := range c.flags {
+			fmt.Fprintf(b, "complete -c %v -n '__%v_is %v'", root.name, root.id, c.id)
+			for _, name := range f.names {
+				if len(name) == 1 {
+					fmt.Fprintf(b, " -s %v", name)
+				} else {
+					fmt.Fprintf(b, " -l %v", name)
+				}
+			}
+			if f.usage != "" {
+				fmt.Fprintf(b, " -d %v", singleQuote(f.usage))
+			}
+			if f.takesValue {
+				if len(f.enums) > 0 {
+					fmt.Fprintf(b, " -x -a %v", singleQuote(strings.Join(f.enums, " ")))
+				} else {
+					b.WriteString(" -r -F")
+				}
+			}
+			b.WriteString("\n")
+		}
+	})
+	return b.String()
+}
+
+func genPowerShellCompletion(root *compCmd) string {
+	b := &strings.Builder{}
+	fmt.Fprintf(b, "# powershell completion for %v, generated by github.com/ondbyte/turbo_flag\n", root.name)
+	fmt.Fprintf(b, "# load it with: %v completion powershell | Out-String | Invoke-Expression\n\n", root.name)
+	fmt.Fprintf(b, "Register-ArgumentCompleter -Native -CommandName %v -ScriptBlock {\n", psQuote(root.name))
+	b.WriteString("    param($wordToComplete, $commandAst, $cursorPosition)\n")
+	b.WriteString("    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })\n")
+	b.WriteString("    if ($wordToComplete -ne '') { $words = $words[0..($words.Count - 2)] }\n")
+	fmt.Fprintf(b, "    $cmd = %v\n", psQuote(root.id))
+	b.WriteString("    $next = 1\n")
+	b.WriteString("    for ($i = 1; $i -lt $words.Count; $i++) {\n")
+	b.WriteString("        if ($i -ne $next) { continue }\n")
+	b.WriteString("        switch (\"$cmd/$($words[$i])\") {\n")
+	writeTransitions(b, root, "            ", "'%v' { $cmd = '%v'; $next = $i + 1 }\n")
+	b.WriteString("        }\n")
+	b.WriteString("    }\n")
+	b.WriteString("    $prev = $words[$words.Count - 1]\n")
+	b.WriteString("    $atSub = ($words.Count -eq $next) -and -not $wordToComplete.StartsWith('-')\n")
+	b.WriteString("    $candidates = switch ($cmd) {\n")
+	root.walk(func(c *compCmd) {
+		fmt.Fprintf(b, "        %v {\n", psQuote(c.id))
+		keyword := "if"
+		for _, f := range c.flags {
+			if !f.takesValue {
+				continue
+			}
+			fmt.Fprintf(b, "            %v (@(%v) -contains $prev) { ", keyword, strings.Join(quoteAll(f.flagWords(), psQuote), ", "))
+			if len(f.enums) > 0 {
+				fmt.Fprintf(b, "@(%v) }\n", strings.Join(quoteAll(f.enums, psQuote), ", "))
+			} else {
+				// returning nothing lets powershell complete the paths
+				b.WriteString("return }\n")
+			}
+			keyword = "elseif"
+		}
+		if len(c.children) > 0 {
+			fmt.Fprintf(b, "            %v ($atSub) { @(%v) }\n", keyword, strings.Join(quoteAll(c.subNames(), psQuote), ", "))
+			keyword = "elseif"
+		}
+		if keyword == "if" {
+			fmt.Fprintf(b, "            @(%v)\n", strings.Join(quoteAll(c.flagWords(), psQuote), ", "))
+		} else {
+			fmt.Fprintf(b, "            else { @(%v) }\n", strings.Join(quoteAll(c.flagWords(), psQuote), ", "))
+		}
+		b.WriteString("        }\n")
+	})
+	b.WriteString("    }\n")
+	b.WriteString("    $candidates | Where-Object { $_ -like \"$wordToComplete*\" } | ForEach-Object {\n")
+	b.WriteString("        [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)\n")
+	b.WriteString("    }\n")
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,398 @@
+package flag
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CompDirective is the hint "__complete" passes to the shell along with the completion candidates
+type CompDirective int
+
+const (
+	// CompDefault lets the shell complete file names when there are no candidates
+	CompDefault CompDirective = 0
+	// CompNoFiles stops the shell from completing file names when there are no candidates
+	CompNoFiles CompDirective = 1
+	// CompFiles makes the shell complete file names, the candidates are the extensions to filter by
+	CompFiles CompDirective = 2
+	// CompDirs makes the shell complete directory names
+	CompDirs CompDirective = 4
+)
+
+// completes the value of the flag you are defining using fn, fn receives the partially typed value
+// and returns the candidates.
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func Complete(fn func(prefix string) []string) *flagFeature {
+	return CommandLine.Complete(fn)
+}
+
+// completes the value of the flag you are defining using fn, fn receives the partially typed value
+// and returns the candidates.
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func (fs *FlagSet) Complete(fn func(prefix string) []string) *flagFeature {
+	return &flagFeature{
+		index: 9,
+		add: func(fs *FlagSet, f *Flag) {
+			f.complete = fn
+		},
+	}
+}
+
+// completes the value of the flag you are defining with file names having one of the exts (without the dot),
+// any file name when no exts are passed.
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func CompleteFiles(exts ...string) *flagFeature {
+	return CommandLine.CompleteFiles(exts...)
+}
+
+// completes the value of the flag you are defining with file names having one of the exts (without the dot),
+// any file name when no exts are passed.
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func (fs *FlagSet) CompleteFiles(exts ...string) *flagFeature {
+	return &flagFeature{
+		index: 9,
+		add: func(fs *FlagSet, f *Flag) {
+			f.compDirective = CompFiles
+			f.compExts = exts
+		},
+	}
+}
+
+// completes the value of the flag you are defining with directory names
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func CompleteDirs() *flagFeature {
+	return CommandLine.CompleteDirs()
+}
+
+// completes the value of the flag you are defining with directory names
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func (fs *FlagSet) CompleteDirs() *flagFeature {
+	return &flagFeature{
+		index: 9,
+		add: func(fs *FlagSet, f *Flag) {
+			f.compDirective = CompDirs
+		},
+	}
+}
+
+// completes the positional arguments of the default flagset using fn
+func CompleteArgs(fn func(prefix string) []string) {
+	CommandLine.CompleteArgs(fn)
+}
+
+// completes the positional arguments of this command using fn, fn receives the partially typed argument
+// and returns the candidates.
+func (fs *FlagSet) CompleteArgs(fn func(prefix string) []string) {
+	fs.completeArgs = fn
+}
+
+// adds the hidden "__complete" and "completion <shell>" sub commands to the default flagset
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func EnableDynamicCompletion() {
+	CommandLine.EnableDynamicCompletion()
+}
+
+// adds the hidden "__complete" sub command which prints the completions for the words following it
+// and a hidden "completion <shell>" sub command which prints the script making the shell call "__complete".
+// it panics when the completion is already enabled, it can't be used along with EnableCompletion.
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func (fs *FlagSet) EnableDynamicCompletion() {
+	fs.checkCompletionCmd()
+	fs.SubCmdFs("__complete", "prints the completions for the words", func(sub *FlagSet, args []string) {
+		candidates, directive := fs.Completions(args)
+		for _, c := range candidates {
+			fmt.Fprintln(os.Stdout, c)
+		}
+		fmt.Fprintf(os.Stdout, ":%d\n", directive)
+	})
+	fs.SubCmds["__complete"].hidden = true
+	fs.addCompletionCmd(fs.GenDynamicCompletion)
+}
+
+// Completions returns the completion candidates for the last of the words and the hint for the shell,
+// words are the arguments typed after the name of this command, the last one being the partially typed
+// word (empty when a new word is being started). a candidate may be followed by a tab and its description.
+// the flags and sub commands of a sub command are completed only when they are declared using SubCmdFlags.
+func (fs *FlagSet) Completions(words []string) ([]string, CompDirective) {
+	if len(words) == 0 {
+		words = []string{""}
+	}
+	prefix := words[len(words)-1]
+	if prefix == `""` {
+		// powershell can't pass an empty argument to the programs
+		prefix = ""
+	}
+	cmd := fs
+	next := 0
+	var valueFlag *Flag
+	for i, word := range words[:len(words)-1] {
+		if valueFlag != nil {
+			valueFlag = nil
+			continue
+		}
+		if i == next {
+			if sc, ok := cmd.SubCmds[word]; ok {
+				cmd = sc.fs
+				next = i + 1
+				continue
+			}
+		}
+		if len(word) > 1 && word[0] == '-' && !strings.Contains(word, "=") {
+			flag := cmd.formal[strings.TrimLeft(word, "-")]
+			if flag != nil && flagTakesValue(flag) {
+				valueFlag = flag
+			}
+		}
+	}
+	if valueFlag != nil {
+		return completeFlagValue(cmd.primaryFlag(valueFlag), prefix, "")
+	}
+	if len(prefix) > 1 && prefix[0] == '-' {
+		if i := strings.Index(prefix, "="); i > 0 {
+			flag := cmd.formal[strings.TrimLeft(prefix[:i], "-")]
+			if flag == nil {
+				return nil, CompNoFiles
+			}
+			return completeFlagValue(cmd.primaryFlag(flag), prefix[i+1:], prefix[:i+1])
+		}
+	}
+	if strings.HasPrefix(prefix, "-") {
+		var candidates []string
+		for _, flag := range sortFlags(cmd.formal) {
+			word := flagWord(flag.Name)
+			if strings.HasPrefix(word, prefix) && !cmd.primaryFlag(flag).hidden {
+				candidates = append(candidates, completion(word, cmd.primaryFlag(flag).Usage))
+			}
+		}
+		return candidates, CompNoFiles
+	}
+	var candidates []string
+	if len(words)-1 == next {
+		for _, sc := range cmd.visibleSubCmds() {
+			if strings.HasPrefix(sc.fs.name, prefix) {
+				candidates = append(candidates, completion(sc.fs.name, sc.fs.usg))
+			}
+		}
+	}
+	if cmd.completeArgs != nil {
+		candidates = append(candidates, filterPrefix(cmd.completeArgs(prefix), prefix, "")...)
+		return candidates, CompNoFiles
+	}
+	if len(candidates) > 0 {
+		return candidates, CompNoFiles
+	}
+	return nil, CompDefault
+}
+
+// primaryFlag returns the flag an alias flag is defined for, flag itself otherwise
+func (fs *FlagSet) primaryFlag(flag *Flag) *Flag {
+	if primary, ok := fs.formal[flag.aliasFor]; ok && flag.aliasFor != "" {
+		return primary
+	}
+	return flag
+}
+
+func flagTakesValue(flag *Flag) bool {
+	fv, ok := flag.Value.(boolFlag)
+	return !(ok && fv.IsBoolFlag())
+}
+
+// completeFlagValue completes the value of flag, candidates are prepended with before
+func completeFlagValue(flag *Flag, prefix string, before string) ([]string, CompDirective) {
+	switch {
+	case len(flag.enums) > 0:
+		return filterPrefix(sortedKeys(flag.enums), prefix, before), CompNoFiles
+	case flag.complete != nil:
+		return filterPrefix(flag.complete(prefix), prefix, before), CompNoFiles
+	case flag.compDirective == CompFiles:
+		return flag.compExts, CompFiles
+	}
+	return nil, flag.compDirective
+}
+
+func filterPrefix(candidates []string, prefix string, before string) []string {
+	var filtered []string
+	for _, c := range candidates {
+		if strings.HasPrefix(c, prefix) {
+			filtered = append(filtered, before+c)
+		}
+	}
+	return filtered
+}
+
+func completion(value string, description string) string {
+	description = strings.Join(strings.Fields(description), " ")
+	if description == "" {
+		return value
+	}
+	return value + "\t" + description
+}
+
+// GenDynamicCompletion writes the script for the shell to w which completes by calling the hidden
+// "__complete" sub command added by EnableDynamicCompletion.
+// supported shells are bash, zsh, fish and powershell.
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func GenDynamicCompletion(w io.Writer, shell string) error {
+	return CommandLine.GenDynamicCompletion(w, shell)
+}
+
+// GenDynamicCompletion writes the script for the shell to w which completes by calling the hidden
+// "__complete" sub command added by EnableDynamicCompletion.
+// supported shells are bash, zsh, fish and powershell.
+// https://github.com/ondbyte/turbo_flag#dynamic-completion
+func (fs *FlagSet) GenDynamicCompletion(w io.Writer, shell string) error {
+	program := filepath.Base(fs.name)
+	var script string
+	switch shell {
+	case "bash":
+		script = bashDynamicCompletion
+	case "zsh":
+		script = zshDynamicCompletion
+	case "fish":
+		script = fishDynamicCompletion
+	case "powershell":
+		script = powerShellDynamicCompletion
+	default:
+		return fs.msgErr(MsgUnsupportedShell, shell, strings.Join(completionShells, ", "))
+	}
+	script = strings.NewReplacer("PROGRAM_ID", shellIdent(program), "PROGRAM", program).Replace(script)
+	_, err := io.WriteString(w, script)
+	return err
+}
+
+// the scripts call "PROGRAM __complete <words>", the output is a candidate per line followed by ":<CompDirective>"
+
+const bashDynamicCompletion = `# bash completion for PROGRAM, generated by github.com/ondbyte/turbo_flag
+# load it with: source <(PROGRAM completion bash)
+
+_PROGRAM_ID() {
+    local IFS=$'\n'
+    local cur="${COMP_WORDS[COMP_CWORD]}" directive ext
+    local -a lines
+    lines=($("${COMP_WORDS[0]}" __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)) || return
+    [[ ${#lines[@]} -gt 0 ]] || return
+    directive="${lines[${#lines[@]}-1]#:}"
+    unset 'lines[${#lines[@]}-1]'
+    COMPREPLY=()
+    if (( directive & 2 )); then
+        if [[ ${#lines[@]} -eq 0 ]]; then
+            COMPREPLY=($(compgen -f -- "${cur}"))
+        else
+            for ext in "${lines[@]}"; do
+                COMPREPLY+=($(compgen -f -X "!*.${ext}" -- "${cur}"))
+            done
+            COMPREPLY+=($(compgen -d -- "${cur}"))
+        fi
+    elif (( directive & 4 )); then
+        COMPREPLY=($(compgen -d -- "${cur}"))
+    else
+        COMPREPLY=($(compgen -W "${lines[*]%%$'\t'*}" -- "${cur}"))
+        if [[ ${#COMPREPLY[@]} -eq 0 ]] && (( (directive & 1) == 0 )); then
+            COMPREPLY=($(compgen -f -- "${cur}"))
+        fi
+    fi
+}
+
+complete -F _PROGRAM_ID PROGRAM
+`
+
+const zshDynamicCompletion = `#compdef PROGRAM
+# zsh completion for PROGRAM, generated by github.com/ondbyte/turbo_flag
+# load it with: source <(PROGRAM completion zsh)
+
+_PROGRAM_ID() {
+    local -a lines candidates
+    local directive line
+    lines=("${(@f)$(${words[1]} __complete "${(@)words[2,CURRENT]}" 2>/dev/null)}")
+    (( ${#lines} )) || return
+    directive="${lines[-1]#:}"
+    lines=("${(@)lines[1,-2]}")
+    lines=(${lines:#})
+    if (( directive & 2 )); then
+        if (( ${#lines} )); then
+            _files -g "*.(${(j:|:)lines})"
+        else
+            _files
+        fi
+    elif (( directive & 4 )); then
+        _files -/
+    else
+        for line in "${lines[@]}"; do
+            if [[ "${line}" == *$'\t'* ]]; then
+                candidates+=("${${line%%$'\t'*}//:/\\:}:${line#*$'\t'}")
+            else
+                candidates+=("${line//:/\\:}")
+            fi
+        done
+        if (( ${#candidates} )); then
+            _describe 'completions' candidates
+        elif (( (directive & 1) == 0 )); then
+            _files
+        fi
+    fi
+}
+
+if [[ "${funcstack[1]}" == "_PROGRAM_ID" ]]; then
+    _PROGRAM_ID "$@"
+else
+    compdef _PROGRAM_ID PROGRAM
+fi
+`
+
+const fishDynamicCompletion = `# fish completion for PROGRAM, generated by github.com/ondbyte/turbo_flag
+# load it with: PROGRAM completion fish | source
+
+function __PROGRAM_ID_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    set -l lines ($tokens[1] __complete $tokens[2..-1] "$current" 2>/dev/null)
+    set -q lines[1]; or return
+    set -l directive (string replace ':' '' -- $lines[-1])
+    set -e lines[-1]
+    if test (math "floor($directive / 2) % 2") -eq 1
+        if set -q lines[1]
+            for ext in $lines
+                __fish_complete_suffix "$current" .$ext
+            end
+        else
+            __fish_complete_path "$current"
+        end
+    else if test (math "floor($directive / 4) % 2") -eq 1
+        __fish_complete_directories "$current"
+    else if set -q lines[1]
+        printf '%s\n' $lines
+    else if test (math "$directive % 2") -eq 0
+        __fish_complete_path "$current"
+    end
+end
+
+complete -c PROGRAM -f -a '(__PROGRAM_ID_complete)'
+`
+
+const powerShellDynamicCompletion = `# powershell completion for PROGRAM, generated by github.com/ondbyte/turbo_flag
+# load it with: PROGRAM completion powershell | Out-String | Invoke-Expression
+
+Register-ArgumentCompleter -Native -CommandName 'PROGRAM' -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+    $words = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })
+    $arguments = @($words | Select-Object -Skip 1)
+    if ($wordToComplete -eq '') { $arguments += '""' }
+    $lines = @(& $words[0] __complete @arguments 2>$null)
+    if ($lines.Count -eq 0) { return }
+    $directive = [int]($lines[-1].TrimStart(':'))
+    $lines = @($lines | Select-Object -SkipLast 1)
+    # returning nothing lets powershell complete the paths
+    if (($directive -band 6) -ne 0) { return }
+    if ($lines.Count -eq 0) { return }
+    $lines | ForEach-Object {
+        $value, $description = $_ -split "` + "`" + `t", 2
+        if (-not $description) { $description = $value }
+        if ($value -like "$wordToComplete*") {
+            [System.Management.Automation.CompletionResult]::new($value, $value, 'ParameterValue', $description)
+        }
+    }
+}
+`
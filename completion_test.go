@@ -0,0 +1,160 @@
+package flag_test
+
+import (
+	"bytes"
+	goflag "flag"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+var update = goflag.Bool("update", false, "update the golden files")
+
+// newCompletionTool returns a command tree to generate the completions and docs from
+func newCompletionTool() *FlagSet {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.Bool("verbose", false, "prints more", fs.Alias("v"))
+	fs.String("region", "eu", "region to work with", fs.Enum("eu", "us"))
+	fs.SubCmdFs("commit", "commits the changes", func(fs *FlagSet, args []string) {
+		panic("commit should not run while generating")
+	})
+	fs.SubCmdFlags("commit", func(cmd CMD) {
+		cmd.String("branch", "main", "branch to commit to", cmd.Alias("b"), cmd.Enum("main", "stable"))
+		cmd.String("message", "", "commit message", cmd.Alias("m"))
+		cmd.Example("tool commit -b stable", "commit to the stable branch")
+		cmd.Example("tool commit -m 'fix the build'", "")
+	})
+	fs.SubCmdFs("remote", "manages the remotes", func(fs *FlagSet, args []string) {
+		panic("remote should not run while generating")
+	})
+	fs.SubCmdFlags("remote", func(cmd CMD) {
+		cmd.SubCmd("add", "adds a remote", func(cmd CMD, args []string) {
+			panic("add should not run while generating")
+		})
+		cmd.SubCmdFlags("add", func(cmd CMD) {
+			cmd.String("name", "origin", "name of the remote")
+		})
+	})
+	fs.EnableCompletion()
+	return fs
+}
+
+func TestFlagSet_GenCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			err := newCompletionTool().GenCompletion(b, shell)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", "completion", "tool."+shell)
+			if *update {
+				err = os.WriteFile(golden, b.Bytes(), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != string(want) {
+				t.Errorf("completion script for %v differs from %v, got\n%v", shell, golden, b.String())
+			}
+		})
+	}
+	err := newCompletionTool().GenCompletion(&bytes.Buffer{}, "cmd")
+	if err == nil {
+		t.Fatal("expected error for unsupported shell")
+	}
+}
+
+func TestFlagSet_Completions(t *testing.T) {
+	newTool := func() *FlagSet {
+		fs := newCompletionTool()
+		fs.String("cfg", "", "config file", fs.CompleteFiles("yaml", "json"))
+		fs.String("out", "", "output directory", fs.CompleteDirs())
+		fs.String("namespace", "", "namespace to use", fs.Alias("n"), fs.Complete(func(prefix string) []string {
+			return []string{"default", "kube-system", "kube-public"}
+		}))
+		fs.CompleteArgs(func(prefix string) []string {
+			return []string{"file1", "file2"}
+		})
+		return fs
+	}
+	tests := []struct {
+		words      []string
+		want       []string
+		wantDirect CompDirective
+	}{
+		{[]string{""}, []string{"commit\tcommits the changes", "remote\tmanages the remotes", "file1", "file2"}, CompNoFiles},
+		{[]string{"co"}, []string{"commit\tcommits the changes"}, CompNoFiles},
+		{[]string{"--re"}, []string{"--region\tregion to work with"}, CompNoFiles},
+		{[]string{"--region", ""}, []string{"eu", "us"}, CompNoFiles},
+		{[]string{"--region=u"}, []string{"--region=us"}, CompNoFiles},
+		{[]string{"-n", "kube"}, []string{"kube-system", "kube-public"}, CompNoFiles},
+		{[]string{"--cfg", ""}, []string{"yaml", "json"}, CompFiles},
+		{[]string{"--out", ""}, nil, CompDirs},
+		{[]string{"commit", "-b", `""`}, []string{"main", "stable"}, CompNoFiles},
+		{[]string{"commit", "--message", "x", ""}, nil, CompDefault},
+		{[]string{"remote", ""}, []string{"add\tadds a remote"}, CompNoFiles},
+		{[]string{"remote", "add", "--"}, []string{"--name\tname of the remote"}, CompNoFiles},
+		{[]string{"--verbose", ""}, []string{"file1", "file2"}, CompNoFiles},
+	}
+	for _, tt := range tests {
+		got, directive := newTool().Completions(tt.words)
+		if !reflect.DeepEqual(got, tt.want) || directive != tt.wantDirect {
+			t.Errorf("Completions(%q) = %q, %v want %q, %v", tt.words, got, directive, tt.want, tt.wantDirect)
+		}
+	}
+}
+
+func TestFlagSet_GenDynamicCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		t.Run(shell, func(t *testing.T) {
+			b := &bytes.Buffer{}
+			err := newCompletionTool().GenDynamicCompletion(b, shell)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden := filepath.Join("testdata", "completion", "tool_dynamic."+shell)
+			if *update {
+				err = os.WriteFile(golden, b.Bytes(), 0644)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if b.String() != string(want) {
+				t.Errorf("completion script for %v differs from %v, got\n%v", shell, golden, b.String())
+			}
+		})
+	}
+}
+
+func TestFlagSet_EnableCompletionTwice(t *testing.T) {
+	enables := map[string]func(fs *FlagSet){
+		"completion": (*FlagSet).EnableCompletion,
+		"dynamic":    (*FlagSet).EnableDynamicCompletion,
+	}
+	for first, enableFirst := range enables {
+		for second, enableSecond := range enables {
+			t.Run(first+" then "+second, func(t *testing.T) {
+				fs := NewFlagSet("tool", ContinueOnError)
+				enableFirst(fs)
+				defer func() {
+					if recover() == nil {
+						t.Error("expected the second completion to panic")
+					}
+				}()
+				enableSecond(fs)
+			})
+		}
+	}
+}
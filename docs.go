@@ -0,0 +1,234 @@
+package flag
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// docCmd is a command in the tree the man pages and the reference docs are generated from
+type docCmd struct {
+	fs       *FlagSet
+	children []*docCmd
+}
+
+func newDocCmd(fs *FlagSet) *docCmd {
+	cmd := &docCmd{fs: fs}
+	for _, sc := range fs.visibleSubCmds() {
+		cmd.children = append(cmd.children, newDocCmd(sc.fs))
+	}
+	return cmd
+}
+
+// walk calls fn for cmd and all of its children, parents first
+func (c *docCmd) walk(fn func(c *docCmd)) {
+	fn(c)
+	for _, child := range c.children {
+		child.walk(fn)
+	}
+}
+
+// docPath returns the names of the commands from the root till fs, the root command is named after the program
+func docPath(fs *FlagSet) []string {
+	path := fs.commandPath()
+	path[0] = filepath.Base(path[0])
+	return path
+}
+
+// docName returns the name of the page of fs without the extension, like "tool-remote-add"
+func docName(fs *FlagSet) string {
+	return strings.Join(docPath(fs), "-")
+}
+
+// docPage is the data a reference page of a command is rendered with
+type docPage struct {
+	UsageData
+	Name   string   // name of the page without the extension, the pages of the sub commands are named Name-<sub command>
+	Ext    string   // extension of the pages, used in the links
+	Parent *docPage // page of the parent command, nil for the root command
+}
+
+func newDocPage(fs *FlagSet, ext string) *docPage {
+	page := &docPage{UsageData: fs.UsageData(true), Name: docName(fs), Ext: ext}
+	page.CommandPath = strings.Join(docPath(fs), " ")
+	if fs.parentCmd != nil {
+		page.Parent = &docPage{Name: docName(fs.parentCmd), Ext: ext}
+		page.Parent.CommandPath = strings.Join(docPath(fs.parentCmd), " ")
+		page.Parent.Description = fs.parentCmd.usg
+	}
+	return page
+}
+
+// docFlagNames returns the names of the flag and its aliases as they are typed, single letter ones first
+func docFlagNames(flag UsageFlag) []string {
+	var short, long []string
+	for _, name := range append([]string{flag.Name}, flag.Aliases...) {
+		if len(name) == 1 {
+			short = append(short, "-"+name)
+		} else {
+			long = append(long, "--"+name)
+		}
+	}
+	return append(short, long...)
+}
+
+// mdCell escapes s to be used in a cell of a markdown table
+func mdCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.ReplaceAll(s, "\n", "<br>")
+}
+
+// mdCode wraps each of the strings in backticks and joins them with ", "
+func mdCode(s []string) string {
+	code := make([]string, len(s))
+	for i, v := range s {
+		code[i] = "`" + mdCell(v) + "`"
+	}
+	return strings.Join(code, ", ")
+}
+
+var docFuncs = map[string]interface{}{
+	"flagNames": docFlagNames,
+	"mdCell":    mdCell,
+	"mdCode":    mdCode,
+	"join":      strings.Join,
+}
+
+var markdownDocTmpl = template.Must(template.New("markdown").Funcs(docFuncs).Parse(`# {{.CommandPath}}
+{{if .Description}}
+{{.Description}}
+{{end}}
+## Usage
+
+` + "```" + `
+{{if .Flags}}{{.CommandPath}} [<flags>]
+{{end}}{{if .SubCmds}}{{.CommandPath}} [<sub-command>]
+{{end}}` + "```" + `
+{{if .SubCmdGroups}}
+## Sub commands
+{{range .SubCmdGroups}}{{if .Title}}
+### {{.Title}}
+{{end}}
+| Command | Description |
+| --- | --- |
+{{range .SubCmds}}| [{{.Name}}]({{$.Name}}-{{.Name}}{{$.Ext}}) | {{mdCell .Usage}} |
+{{end}}{{end}}{{end}}{{if .FlagGroups}}
+## Flags
+{{range .FlagGroups}}{{if .Title}}
+### {{.Title}}
+{{end}}
+| Flag | Type | Default | Description | Env | Config |
+| --- | --- | --- | --- | --- | --- |
+{{range .Flags}}| {{mdCode (flagNames .)}} | {{or .Type "bool"}} | ` + "`" + `{{printf "%q" .Default}}` + "`" + ` | {{mdCell .Usage}}{{if .Enums}} (possible values {{mdCode .Enums}}){{end}} | {{mdCode .Envs}} | {{mdCode .Cfgs}} |
+{{end}}{{end}}{{end}}{{if .Examples}}
+## Examples
+{{range .Examples}}{{if .Description}}
+{{.Description}}
+{{end}}
+` + "```" + `
+{{.Command}}
+` + "```" + `
+{{end}}{{end}}{{if .Parent}}
+## See also
+
+- [{{.Parent.CommandPath}}]({{.Parent.Name}}{{.Ext}}){{if .Parent.Description}} - {{.Parent.Description}}{{end}}
+{{end}}`))
+
+var htmlDocTmpl = htmltemplate.Must(htmltemplate.New("html").Funcs(docFuncs).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.CommandPath}}</title>
+</head>
+<body>
+<h1>{{.CommandPath}}</h1>
+{{if .Description}}<p>{{.Description}}</p>
+{{end}}<h2>Usage</h2>
+<pre>
+{{if .Flags}}{{.CommandPath}} [&lt;flags&gt;]
+{{end}}{{if .SubCmds}}{{.CommandPath}} [&lt;sub-command&gt;]
+{{end}}</pre>
+{{if .SubCmdGroups}}<h2>Sub commands</h2>
+{{range .SubCmdGroups}}{{if .Title}}<h3>{{.Title}}</h3>
+{{end}}<table>
+<tr><th>Command</th><th>Description</th></tr>
+{{range .SubCmds}}<tr><td><a href="{{$.Name}}-{{.Name}}{{$.Ext}}">{{.Name}}</a></td><td>{{.Usage}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}{{if .FlagGroups}}<h2>Flags</h2>
+{{range .FlagGroups}}{{if .Title}}<h3>{{.Title}}</h3>
+{{end}}<table>
+<tr><th>Flag</th><th>Type</th><th>Default</th><th>Description</th><th>Env</th><th>Config</th></tr>
+{{range .Flags}}<tr><td><code>{{join (flagNames .) ", "}}</code></td><td>{{or .Type "bool"}}</td><td><code>{{printf "%q" .Default}}</code></td><td>{{.Usage}}{{if .Enums}} (possible values <code>{{join .Enums ", "}}</code>){{end}}</td><td>{{if .Envs}}<code>{{join .Envs ", "}}</code>{{end}}</td><td>{{if .Cfgs}}<code>{{join .Cfgs ", "}}</code>{{end}}</td></tr>
+{{end}}</table>
+{{end}}{{end}}{{if .Examples}}<h2>Examples</h2>
+{{range .Examples}}{{if .Description}}<p>{{.Description}}</p>
+{{end}}<pre>{{.Command}}</pre>
+{{end}}{{end}}{{if .Parent}}<h2>See also</h2>
+<ul>
+<li><a href="{{.Parent.Name}}{{.Ext}}">{{.Parent.CommandPath}}</a>{{if .Parent.Description}} - {{.Parent.Description}}{{end}}</li>
+</ul>
+{{end}}</body>
+</html>
+`))
+
+// docTemplate is implemented by both text/template and html/template
+type docTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// genDocs writes a page rendered with tmpl for fs and each of its sub commands to dir
+func (fs *FlagSet) genDocs(dir string, ext string, tmpl docTemplate) error {
+	var err error
+	newDocCmd(fs).walk(func(c *docCmd) {
+		if err != nil {
+			return
+		}
+		path := filepath.Join(dir, docName(c.fs)+ext)
+		var file *os.File
+		file, err = os.Create(path)
+		if err != nil {
+			err = fmt.Errorf("unable to create the doc %v : %v", path, err)
+			return
+		}
+		err = tmpl.Execute(file, newDocPage(c.fs, ext))
+		if err != nil {
+			err = fmt.Errorf("unable to render the doc %v : %v", path, err)
+		}
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("unable to write the doc %v : %v", path, closeErr)
+		}
+	})
+	return err
+}
+
+// GenMarkdownDocs writes the markdown reference of the default flagset and its sub commands to the directory dir
+// https://github.com/ondbyte/turbo_flag#reference-docs
+func GenMarkdownDocs(dir string) error {
+	return CommandLine.GenMarkdownDocs(dir)
+}
+
+// GenMarkdownDocs writes a markdown page for this command and each of its sub commands to the directory dir,
+// the pages are named after the command path like "tool-remote-add.md" and link to each other.
+// the pages of the sub commands list only the flags and sub commands declared using SubCmdFlags.
+// https://github.com/ondbyte/turbo_flag#reference-docs
+func (fs *FlagSet) GenMarkdownDocs(dir string) error {
+	return fs.genDocs(dir, ".md", markdownDocTmpl)
+}
+
+// GenHTMLDocs writes the html reference of the default flagset and its sub commands to the directory dir
+// https://github.com/ondbyte/turbo_flag#reference-docs
+func GenHTMLDocs(dir string) error {
+	return CommandLine.GenHTMLDocs(dir)
+}
+
+// GenHTMLDocs writes a html page for this command and each of its sub commands to the directory dir,
+// the pages are named after the command path like "tool-remote-add.html" and link to each other.
+// the pages of the sub commands list only the flags and sub commands declared using SubCmdFlags.
+// https://github.com/ondbyte/turbo_flag#reference-docs
+func (fs *FlagSet) GenHTMLDocs(dir string) error {
+	return fs.genDocs(dir, ".html", htmlDocTmpl)
+}
@@ -0,0 +1,37 @@
+package flag_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func newDocsTool() *FlagSet {
+	fs := newCompletionTool()
+	fs.SetUsage("tool manages | your things")
+	fs.String("token", "", "token to authenticate with", fs.Env("TOOL_TOKEN"), fs.Cfg("auth.token"), fs.Group("Auth"))
+	return fs
+}
+
+func TestFlagSet_GenMarkdownDocs(t *testing.T) {
+	dir := t.TempDir()
+	err := newDocsTool().GenMarkdownDocs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareGoldenDir(t, dir, filepath.Join("testdata", "docs", "md"), 4)
+}
+
+func TestFlagSet_GenHTMLDocs(t *testing.T) {
+	dir := t.TempDir()
+	err := newDocsTool().GenHTMLDocs(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareGoldenDir(t, dir, filepath.Join("testdata", "docs", "html"), 4)
+	err = newDocsTool().GenHTMLDocs(filepath.Join(dir, "missing"))
+	if err == nil {
+		t.Fatal("expected error for a missing directory")
+	}
+}
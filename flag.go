@@ -1,16 +1,19 @@
 package flag
 
 import (
+	"context"
 	"encoding"
 	"errors"
 	"fmt"
 	"io"
+	iofs "io/fs"
 	"os"
-	"path/filepath"
 	"reflect"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 )
 
@@ -283,6 +286,13 @@ type Getter interface {
 	Get() any
 }
 
+// SliceValue is a Value holding many values, the values matched by a cfg binding with a
+// wildcard like servers.*.host replace its values rather than being Set one by one.
+type SliceValue interface {
+	Value
+	Replace(values []string) error
+}
+
 // ErrorHandling defines how FlagSet.Parse behaves if the parse fails.
 type ErrorHandling int
 
@@ -305,8 +315,31 @@ type Flag struct {
 	enums    map[string]bool
 	alias    map[string]bool
 	aliasFor string //this flag is an alias for
+
+	complete      func(prefix string) []string // returns the completion candidates for the value of the flag
+	compDirective CompDirective                // hint passed to the shell while completing the value of the flag
+	compExts      []string                     // extensions to complete with CompFiles
+	group         string                       // title of the section the flag is listed under in the usage
+	required      bool                         // Parse fails when the flag is not set from args, env or cfg
+	source        flagSource                   // where the current value of the flag came from
+	hidden        bool                         // hidden flags work but are not listed in usage, completions and docs
+	deprecated    string                       // warning printed when the flag is set, empty if the flag is not deprecated
+	replacement   string                       // name of the flag the value of the deprecated flag is forwarded to
+	usages        map[string]string            // usage of the flag per locale
+	usageKey      string                       // key of the message used as the usage, for the flags the package defines
+	flagSet       *FlagSet                     // the FlagSet the flag is defined in, its catalog is used for the errors
 }
 
+// flagSource is where the value of a flag came from
+type flagSource int
+
+const (
+	fromDefault flagSource = iota
+	fromEnv
+	fromCfg
+	fromArgs
+)
+
 func isEnumValid(e string, enums []string) bool {
 	valid := false
 	if len(enums) > 0 {
@@ -323,7 +356,11 @@ func isEnumValid(e string, enums []string) bool {
 
 func (f *Flag) Set(s string) error {
 	if !isEnumValid(s, keys(f.enums)) {
-		return fmt.Errorf("flag %v is a enum flag, needs one of these values %v", f.Name, strings.Join(keys(f.enums), ", "))
+		catalog := Catalog(English)
+		if f.flagSet != nil {
+			catalog = f.flagSet.catalog()
+		}
+		return errors.New(catalog.Message(MsgInvalidEnum, f.Name, strings.Join(sortedKeys(f.enums), ", ")))
 	}
 	return f.Value.Set(s)
 }
@@ -336,6 +373,13 @@ func keys(m map[string]bool) []string {
 	return keys
 }
 
+// sortedKeys returns the keys of m in lexicographical sorted order
+func sortedKeys(m map[string]bool) []string {
+	keys := keys(m)
+	sort.Strings(keys)
+	return keys
+}
+
 func qKeys(m map[string]bool) []string {
 	keys := make([]string, 0, len(m))
 	for key := range m {
@@ -596,7 +640,7 @@ func (f *FlagSet) varErr(value Value, name string, usage string, features ...*fl
 	}
 
 	// Remember the default value as a string; it won't change.
-	flag := &Flag{Name: name, Usage: usage, Value: value, DefValue: value.String(), envs: make(map[string]bool), cfgs: make(map[string]bool), enums: make(map[string]bool), alias: make(map[string]bool)}
+	flag := &Flag{Name: name, Usage: usage, Value: value, DefValue: value.String(), envs: make(map[string]bool), cfgs: make(map[string]bool), enums: make(map[string]bool), alias: make(map[string]bool), flagSet: f}
 	_, alreadythere := f.formal[name]
 	if alreadythere {
 		var msg string
@@ -616,12 +660,34 @@ func (f *FlagSet) varErr(value Value, name string, usage string, features ...*fl
 	for _, feature := range sortedFeatures {
 		feature.add(f, flag)
 	}
+	// a deprecated flag defined before this one may already be set from env or cfg
+	for _, deprecated := range f.formal {
+		if deprecated.replacement == name && deprecated.source != fromDefault {
+			f.forward(deprecated)
+		}
+	}
 	return flag, nil
 }
 
 type subCommand struct {
-	fn func(fs *FlagSet, args []string)
-	fs *FlagSet
+	fn     func(fs *FlagSet, args []string)
+	fs     *FlagSet
+	hidden bool   // hidden sub commands work but are not listed in usage, completions and docs
+	group  string // title of the section the sub command is listed under in the usage
+}
+
+// visibleSubCmds returns the sub commands which are not hidden sorted by their name
+func (f *FlagSet) visibleSubCmds() []*subCommand {
+	subCmds := make([]*subCommand, 0, len(f.SubCmds))
+	for _, sc := range f.SubCmds {
+		if !sc.hidden {
+			subCmds = append(subCmds, sc)
+		}
+	}
+	sort.Slice(subCmds, func(i, j int) bool {
+		return subCmds[i].fs.name < subCmds[j].fs.name
+	})
+	return subCmds
 }
 
 // A FlagSet represents a set of defined flags. The zero value of a FlagSet
@@ -648,9 +714,29 @@ type FlagSet struct {
 	errorHandling ErrorHandling
 	output        io.Writer // Deprecated: nil means stderr; use Output() accessor
 	cfgPath       string
+	cfgPaths      []string          // paths of the cfg files loaded, in the order they were merged
+	cfgSources    map[string]string // path of the cfg file each dot notation came from
+	arrayMerge    ArrayMerge
+	cfgFormats    []CfgFormat   // formats registered for this command, only on the root command
+	cfgFormatExt  string        // extension of the format of the cfg files without one
+	expandEnv     bool          // expand the envs in the values of the cfg files, only on the root command
+	cfgMu         sync.RWMutex  // held while the cfg and the flags bound to it change, only on the root command
+	watchInterval time.Duration // how often WatchCfg polls the cfg files, 0 means every second
+	cfgFlag       *cfgFlagCfg   // nil means the cfg flag of the parent command, if any
 	cfg           map[string]interface{}
 	SubCmds       map[string]*subCommand
 	parentCmd     *FlagSet
+	plugins       *pluginCfg                   // nil means plugin discovery is disabled unless a parent command enabled it
+	completeArgs  func(prefix string) []string // returns the completion candidates for the positional arguments
+	usageTmpl     *template.Template           // nil means the template of the parent command or the default one
+	usageWidth    int                          // 0 means the width of the parent command or the terminal
+	flagGroups    []string                     // titles of the flag groups in the order they were declared
+	subCmdGroups  []string                     // titles of the sub command groups in the order they were declared
+	examples      []UsageExample               // example invocations listed in the usage
+	warnOutput    io.Writer                    // nil means the writer of the parent command or stderr
+	colorMode     ColorMode                    // ColorAuto means the mode of the parent command
+	messages      Catalog                      // nil means the catalog of the parent command or English
+	theme         *Theme                       // nil means the theme of the parent command or DefaultTheme
 }
 
 // sortFlags returns the flags as a slice in lexicographical sorted order.
@@ -684,6 +770,17 @@ func (f *FlagSet) SetOutput(output io.Writer) {
 	f.output = output
 }
 
+// Output returns the destination for usage and error messages set on this command or the closest
+// parent command. os.Stderr is returned if output was not set or was set to nil.
+func (f *FlagSet) Output() io.Writer {
+	for cmd := f; cmd != nil; cmd = cmd.parentCmd {
+		if cmd.output != nil {
+			return cmd.output
+		}
+	}
+	return os.Stderr
+}
+
 // VisitAll visits the flags in lexicographical order, calling fn for each.
 // It visits all flags, even those not set.
 func (f *FlagSet) VisitAll(fn func(*Flag)) {
@@ -727,7 +824,7 @@ func Lookup(name string) *Flag {
 func (f *FlagSet) Set(name, value string) error {
 	flag, ok := f.formal[name]
 	if !ok {
-		return fmt.Errorf("no such flag -%v", name)
+		return f.msgErr(MsgNoSuchFlag, name)
 	}
 	err := flag.Set(value)
 	if err != nil {
@@ -737,6 +834,7 @@ func (f *FlagSet) Set(name, value string) error {
 		f.actual = make(map[string]*Flag)
 	}
 	f.actual[name] = flag
+	f.markSet(flag, fromArgs)
 	return nil
 }
 
@@ -839,7 +937,14 @@ func GetDefaultUsageLong() (usage string, err error) {
 	return CommandLine.GetDefaultUsageLong()
 }
 
-// returns a well formatted short usage to print while user passes help flag
+// writes the usage of the default flagset to w, styled when w is a terminal
+// https://github.com/ondbyte/turbo_flag#colors
+func WriteUsage(w io.Writer, long bool) error {
+	return CommandLine.WriteUsage(w, long)
+}
+
+// returns a well formatted short usage to print while user passes help flag, it is styled only with
+// ColorAlways as where it is written is not known, use WriteUsage to style it for a terminal
 func (f *FlagSet) GetDefaultUsage() (usage string, err error) {
 	return f.getDefaultUsage(true)
 }
@@ -849,85 +954,38 @@ func (f *FlagSet) GetDefaultUsageLong() (usage string, err error) {
 	return f.getDefaultUsage(false)
 }
 
+// writes the usage of this command to w, the detailed one when long is true. it is styled when w is a
+// terminal, following SetColor.
+// https://github.com/ondbyte/turbo_flag#colors
+func (f *FlagSet) WriteUsage(w io.Writer, long bool) error {
+	return f.writeUsage(w, long, f.style(w))
+}
+
+// commandPath returns the names of the commands starting from the root command till this one
+func (f *FlagSet) commandPath() []string {
+	path := []string{f.name}
+	for currentCmd := f.parentCmd; currentCmd != nil; currentCmd = currentCmd.parentCmd {
+		path = append([]string{currentCmd.name}, path...)
+	}
+	return path
+}
+
 // returns a well formatted usage to print while user passes help flag
 func (f *FlagSet) getDefaultUsage(short bool) (usage string, err error) {
-	var isZeroValueErrs []error
-	defaultUsage := ""
-	if f.usg != "" {
-		defaultUsage += fmt.Sprintf("%s\n\n", f.usg)
-	}
-	hasFlags := len(f.formal) > 0
-	hasSubCmds := len(f.SubCmds) > 0
+	b := &strings.Builder{}
+	err = f.writeUsage(b, !short, f.style(nil))
+	return b.String(), err
+}
 
-	currentCmd := f
-	commandName := currentCmd.name
-	for {
-		if currentCmd.parentCmd != nil {
-			commandName = currentCmd.parentCmd.name + " " + commandName
-			currentCmd = currentCmd.parentCmd
-		} else {
-			break
-		}
-	}
-	if hasFlags {
-		defaultUsage += fmt.Sprintf("usage:\n  %v [<flags>]\n", commandName)
-	}
-	if hasFlags && hasSubCmds {
-		defaultUsage += "  or\n"
-	}
-	if hasSubCmds {
-		defaultUsage += fmt.Sprintf("  %v [<sub-command>]\n", commandName)
-	}
-	// list of subcommands
-	if len(f.SubCmds) > 0 {
-		defaultUsage += "\n"
-		defaultUsage += "Available sub commands:\n"
-		for _, sc := range f.SubCmds {
-			defaultUsage += ("  " + sc.fs.name + "  " + sc.fs.usg + "\n")
-		}
-	}
-	if hasFlags {
-		defaultUsage += "\nFlags:\n"
-		for _, flag := range f.formal {
-			usage := flag.Usage
-			if flag.aliasFor == "" {
-				if usage == "" {
-					usage = "usage not available"
-				}
-				bracketUsage := fmt.Sprintf("defaults to \"%v\"", flag.DefValue)
-				if !short {
-					if len(flag.enums) > 0 {
-						bracketUsage += fmt.Sprintf(", possible values [%v]", strings.Join(qKeys(flag.enums), ", "))
-					}
-					if len(flag.alias) > 0 {
-						bracketUsage += fmt.Sprintf(", alias [%v]", strings.Join(qKeys(flag.alias), ", "))
-					}
-					if len(flag.envs) > 0 {
-						bracketUsage += fmt.Sprintf(", binds to env/s [%v]", strings.Join(qKeys(flag.envs), ", "))
-					}
-					if len(flag.cfgs) > 0 {
-						bracketUsage += fmt.Sprintf(", binds to cfg/s [%v]", strings.Join(qKeys(flag.cfgs), ", "))
-					}
-				}
-				defaultUsage += fmt.Sprintf("  --%v %v  %v, (%v)\n", flag.Name, valueTypeName(flag.Value), usage, bracketUsage)
-			} else {
-				defaultUsage += fmt.Sprintf("  --%v %v  alias for \"--%v\"\n", flag.Name, valueTypeName(flag.Value), flag.aliasFor)
-			}
-			if hasSubCmds {
-				defaultUsage += fmt.Sprintf("\nUse \"%v [command] --help\" for more information about a command.", commandName)
-			}
-		}
-	}
-	errS := ""
-	if errs := isZeroValueErrs; len(errs) > 0 {
-		for _, err := range errs {
-			errS += (err.Error() + "\n")
-		}
-	}
-	if errS != "" {
-		err = errors.New(errS)
+// writeUsage renders the usage template to w with the style
+func (f *FlagSet) writeUsage(w io.Writer, long bool, style Style) error {
+	data := f.UsageData(long)
+	data.Style = style
+	err := f.usageTemplate().Execute(w, data)
+	if err != nil {
+		return fmt.Errorf("unable to render the usage of %v : %v", f.name, err)
 	}
-	return defaultUsage, err
+	return nil
 }
 
 // PrintDefaults prints, to standard error unless configured otherwise,
@@ -1051,7 +1109,7 @@ func (f *FlagSet) parseOne() (bool, error) {
 	}
 	name := s[numMinuses:]
 	if len(name) == 0 || name[0] == '-' || name[0] == '=' {
-		return false, fmt.Errorf("bad flag syntax: %s", s)
+		return false, f.msgErr(MsgBadFlagSyntax, s)
 	}
 
 	// it's a flag. does it have an argument?
@@ -1069,17 +1127,17 @@ func (f *FlagSet) parseOne() (bool, error) {
 	m := f.formal
 	flag, alreadythere := m[name] // BUG
 	if !alreadythere {
-		return false, fmt.Errorf("flag provided but not defined: -%s", name)
+		return false, f.msgErr(MsgFlagNotDefined, name)
 	}
 
 	if fv, ok := flag.Value.(boolFlag); ok && fv.IsBoolFlag() { // special case: doesn't need an arg
 		if hasValue {
 			if err := fv.Set(value); err != nil {
-				return false, fmt.Errorf("invalid boolean value %q for -%s: %v", value, name, err)
+				return false, f.msgErr(MsgInvalidBoolValue, value, name, err)
 			}
 		} else {
 			if err := fv.Set("true"); err != nil {
-				return false, fmt.Errorf("invalid boolean flag %s: %v", name, err)
+				return false, f.msgErr(MsgInvalidBoolFlag, name, err)
 			}
 		}
 	} else {
@@ -1090,16 +1148,17 @@ func (f *FlagSet) parseOne() (bool, error) {
 			value, f.args = f.args[0], f.args[1:]
 		}
 		if !hasValue {
-			return false, fmt.Errorf("flag needs an argument: -%s", name)
+			return false, f.msgErr(MsgFlagNeedsArgument, name)
 		}
 		if err := flag.Set(value); err != nil {
-			return false, fmt.Errorf("invalid value %q for flag -%s: %v", value, name, err)
+			return false, f.msgErr(MsgInvalidValue, value, name, err)
 		}
 	}
 	if f.actual == nil {
 		f.actual = make(map[string]*Flag)
 	}
 	f.actual[name] = flag
+	f.markSet(flag, fromArgs)
 	return true, nil
 }
 
@@ -1108,8 +1167,12 @@ func (f *FlagSet) parseOne() (bool, error) {
 // still takes in arguments to parse the sub commands passed and run it
 func (f *FlagSet) ParseWithoutArgs(args []string) error {
 	// it is possible that user is trying run a sub-command
-	_, err := f.parseSubCommandAndRun(args)
-	return err
+	ran, err := f.parseSubCommandAndRun(args)
+	if err != nil || ran {
+		return err
+	}
+	// the arguments are not considered, but the env of the cfg flag is
+	return f.loadCfgFlag(nil)
 }
 
 // ParseWithoutArgs parses everything like binding cfg, binding env, binding to other flags etc but arguments passed to the
@@ -1125,7 +1188,10 @@ func (f *FlagSet) parseSubCommandAndRun(args []string) (bool, error) {
 	if ok {
 		sc, ok := f.SubCmds[SubCmdFsName]
 		if !ok {
-			return false, fmt.Errorf("you are trying to run subcommand with name %v but it doesn't exist", SubCmdFsName)
+			if path, found := f.lookupPlugin(SubCmdFsName); found {
+				return true, f.runPlugin(SubCmdFsName, path, SubCmdFsArgs)
+			}
+			return false, f.msgErr(MsgSubCmdNotFound, SubCmdFsName)
 		}
 		sc.fn(sc.fs, SubCmdFsArgs)
 	}
@@ -1151,6 +1217,10 @@ func (f *FlagSet) handleError(err error) error {
 		if err == ErrHelp {
 			os.Exit(0)
 		}
+		var pluginErr *PluginExitError
+		if errors.As(err, &pluginErr) {
+			os.Exit(pluginErr.Code)
+		}
 		os.Exit(2)
 	case PanicOnError:
 		panic(err)
@@ -1174,6 +1244,10 @@ func (f *FlagSet) Parse(arguments []string) error {
 		// then we shouldn't continue running the parent command
 		return nil
 	}
+	err = f.loadCfgFlag(arguments)
+	if err != nil {
+		return f.handleError(err)
+	}
 	f.parsed = true
 	f.args = arguments
 	for {
@@ -1186,6 +1260,24 @@ func (f *FlagSet) Parse(arguments []string) error {
 		}
 		return f.handleError(err)
 	}
+	err = f.checkRequired()
+	if err != nil {
+		return f.handleError(err)
+	}
+	return nil
+}
+
+// checkRequired returns an error listing the required flags which are not set from args, env or cfg
+func (f *FlagSet) checkRequired() error {
+	var missing []string
+	for _, flag := range sortFlags(f.formal) {
+		if flag.required && flag.aliasFor == "" && flag.source == fromDefault {
+			missing = append(missing, "-"+flag.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return f.msgErr(MsgRequiredNotSet, strings.Join(missing, ", "))
+	}
 	return nil
 }
 
@@ -1225,11 +1317,160 @@ type CMD interface {
 	// loads a configuration file at path to this command so you can bind configurations
 	LoadCfg(path string) (err error)
 
+	// loads the configuration from r in the format like "yaml"
+	LoadCfgReader(r io.Reader, format string) error
+
+	// loads the configuration from b in the format like "yaml"
+	LoadCfgBytes(b []byte, format string) error
+
+	// loads the configuration file at path in fsys, like an embed.FS
+	LoadCfgFS(fsys iofs.FS, path string) error
+
+	// writes the effective configuration of this command and its sub commands to w in the format like "yaml"
+	WriteCfg(w io.Writer, format string, dump CfgDump) error
+
+	// saves the effective configuration of this command and its sub commands to the file at path
+	SaveCfg(path string, dump CfgDump) error
+
+	// watches the loaded configuration files and reloads them when they change until ctx is done
+	WatchCfg(ctx context.Context, onChange func(changed []string, err error)) error
+
+	// sets how often WatchCfg polls the configuration files
+	SetWatchInterval(interval time.Duration)
+
+	// runs fn while the configuration and the flags bound to it are not being changed
+	View(fn func())
+
+	// expands ${VAR}, ${VAR:-default} and ${VAR:?message} in the values of the configuration files loaded after it
+	EnableEnvExpansion()
+
+	// returns the value at the dot notation in the configuration as a string
+	GetString(notation string) string
+
+	// returns the value at the dot notation in the configuration as an int
+	GetInt(notation string) int
+
+	// returns the value at the dot notation in the configuration as a bool
+	GetBool(notation string) bool
+
+	// returns the value at the dot notation in the configuration as a duration
+	GetDuration(notation string) time.Duration
+
+	// returns the values at the dot notation in the configuration as strings
+	GetStringSlice(notation string) []string
+
+	// returns a copy of the map at the dot notation in the configuration
+	GetStringMap(notation string) map[string]interface{}
+
+	// reports whether the configuration has a value at the dot notation
+	IsSet(notation string) bool
+
+	// returns the dot notations of all the values in the configuration
+	AllKeys() []string
+
+	// loads the configuration files at paths merging them in order, later files win key by key
+	LoadCfgs(paths ...string) error
+
+	// merges the configuration file at path into the loaded configuration
+	MergeCfg(path string) error
+
+	// registers a configuration format for this command and its sub commands
+	RegisterCfgFormat(format CfgFormat)
+
+	// sets the format of the configuration files without an extension, like "yaml"
+	SetCfgFormat(ext string)
+
+	// sets how the arrays are merged by LoadCfgs and MergeCfg
+	SetArrayMerge(strategy ArrayMerge)
+
+	// returns the path of the configuration file the value at the dot notation came from
+	CfgSource(notation string) string
+
+	// defines a flag named name on this command and its sub commands which loads the configuration file passed to it
+	EnableCfgFlag(name string, envs ...string)
+
+	// finds the configuration file named name in the standard places and loads the first one found
+	FindCfg(name string, dirs ...string) (string, error)
+
+	// finds the configuration files named name in the standard places and loads all of them merged
+	FindCfgs(name string, dirs ...string) ([]string, error)
+
+	// turns on git style plugin discovery, sub commands which are not defined will be looked up
+	// as executables named <root>-<name> in dirs (or PATH when no dirs are passed) and executed
+	EnablePlugins(dirs ...string)
+
+	// adds a hidden "completion <shell>" sub command which prints the completion script for the shell
+	EnableCompletion()
+
+	// adds the hidden "__complete" sub command the shell calls to get the completions at runtime
+	// and a hidden "completion <shell>" sub command which prints the script calling it
+	EnableDynamicCompletion()
+
+	// completes the positional arguments of this command using fn
+	CompleteArgs(fn func(prefix string) []string)
+
+	// completes the value of the flag you are defining using fn
+	Complete(fn func(prefix string) []string) *flagFeature
+
+	// completes the value of the flag you are defining with file names having one of the extensions
+	CompleteFiles(exts ...string) *flagFeature
+
+	// completes the value of the flag you are defining with directory names
+	CompleteDirs() *flagFeature
+
+	// replaces the template the usage of this command and its sub commands is rendered with
+	SetUsageTemplate(text string) error
+
+	// sets the width the usage of this command and its sub commands is wrapped to
+	SetUsageWidth(width int)
+
+	// returns the data the usage template is rendered with
+	UsageData(long bool) UsageData
+
+	// lists the flag you are defining under a section titled title in the usage
+	Group(title string) *flagFeature
+
+	// lists the sub commands with names under a section titled title in the usage
+	SubCmdGroup(title string, names ...string)
+
+	// adds an example invocation of this command with a description to the usage
+	Example(command string, description string)
+
+	// hides the flag you are defining from the usage, completions and docs
+	Hidden() *flagFeature
+
+	// deprecates the flag you are defining, a warning is printed when it is set and its value is forwarded to replacement
+	Deprecated(msg string, replacement string) *flagFeature
+
+	// sets the writer the warnings of this command and its sub commands are printed to
+	SetWarningOutput(w io.Writer)
+
+	// sets the catalog the usage, errors and warnings of this command and its sub commands are taken from
+	SetCatalog(catalog Catalog)
+
+	// sets the usage of the flag you are defining for a locale
+	LocalUsage(locale string, usage string) *flagFeature
+
+	// sets whether the usage, errors and warnings of this command and its sub commands are styled
+	SetColor(mode ColorMode)
+
+	// replaces the theme the usage, errors and warnings of this command and its sub commands are styled with
+	SetTheme(theme Theme)
+
+	// marks the flag you are defining as required, Parse fails when it is not set from args, env or cfg
+	Required() *flagFeature
+
+	// returns a serializable description of this command and its sub commands
+	Schema() Schema
+
 	// introduces a subcommand to this command
 	// you can pass a callback which will recieve a new CMD with name name and args you should parse with the CMD
 	// you recieved after defining the flags
 	SubCmd(name string, usage string, fn func(cmd CMD, args []string))
 
+	// declares the flags and sub commands of the sub command name without running it
+	SubCmdFlags(name string, declare func(cmd CMD))
+
 	// add the values possible for the flag you are defining
 	Enum(enums ...string) *flagFeature
 
@@ -1327,6 +1568,9 @@ type CMD interface {
 	// returns a well formatted detailed (with additional details about the features of the flag) usage to print while user passes help flag
 	GetDefaultUsageLong() (usage string, err error)
 
+	// writes the usage to w, styled when w is a terminal
+	WriteUsage(w io.Writer, long bool) error
+
 	// Func defines a flag with specified name, usage string, and function to be called when the flag is parsed.
 	// The provided function is called with the flag's value as its argument.
 	Func(name, usage string, fn func(string) error, features ...*flagFeature)
@@ -1459,7 +1703,7 @@ func LoadCfg(path string) (err error) {
 	return CommandLine.LoadCfg(path)
 }
 
-// loads a cfg to this flagset
+// loads a cfg to this flagset, "-" reads it from stdin in the format set using SetCfgFormat
 // any sub command defined will also derive from this
 func (fs *FlagSet) LoadCfg(path string) (err error) {
 	if fs.parentCmd != nil {
@@ -1469,44 +1713,55 @@ func (fs *FlagSet) LoadCfg(path string) (err error) {
 		return fmt.Errorf("path is empty while loading config")
 	}
 
-	fs.cfgPath = path
-	b, err := os.ReadFile(path)
-	if err != nil {
-		return fmt.Errorf("failed to read config file at %v : %v", path, err)
-	}
-	fileContent := string(b)
-	mapContent := make(map[string]interface{})
-	ext := strings.ToUpper(filepath.Ext(path))
-	switch ext {
-	case "":
-		return fmt.Errorf("config file has no extension, add a supported extension [YAML,YML,JSON,PROPERTIES]")
-	case ".JSON":
-		mapContent, err = JSONToMap(fileContent)
-		break
-	case ".YML", ".YAML":
-		mapContent, err = YAMLToMap(fileContent)
-		break
-	case ".TOML":
-		mapContent, err = TOMLToMap(fileContent)
-		break
-	default:
-		return fmt.Errorf("unsupported extension %v", ext)
-	}
+	mapContent, err := fs.readCfgFile(path)
 	if err != nil {
-		return fmt.Errorf("unable to read config file : %v", err)
+		return err
 	}
-	fs.cfg = mapContent
-	bindCfgRecursiveAfterLoadCfg(fs)
+	fs.setCfg(mapContent, path, []string{path})
 	return nil
 }
 
+// setCfg replaces the cfg with content loaded from source, the files at paths, and sets the flags bound to it again
+func (fs *FlagSet) setCfg(content map[string]interface{}, source string, paths []string) {
+	fs.cfgMu.Lock()
+	defer fs.cfgMu.Unlock()
+	fs.cfgPath = source
+	fs.cfgPaths = paths
+	fs.cfg = make(map[string]interface{})
+	fs.cfgSources = make(map[string]string)
+	deepMerge(fs.cfg, content, ArrayReplace, source, fs.cfgSources, "")
+	bindCfgRecursiveAfterLoadCfg(fs)
+}
+
 func bindCfgRecursiveAfterLoadCfg(fs *FlagSet) {
+	err := bindCfgTree(fs)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// bindCfgTree sets the flags of fs and the sub commands it holds from the cfg of fs
+func bindCfgTree(fs *FlagSet) error {
 	for _, sc := range fs.SubCmds {
-		bindCfgRecursiveAfterLoadCfg(sc.fs)
+		// the sub commands hold the cfg of the parent from the time they were added
+		sc.fs.cfgPath = fs.cfgPath
+		sc.fs.cfg = fs.cfg
+		err := bindCfgTree(sc.fs)
+		if err != nil {
+			return err
+		}
 	}
 	for _, flag := range fs.formal {
-		fs.bindCfg(flag, keys(flag.cfgs)...)
+		// aliases share the value and the cfgs of their flag, the arguments win over the cfg
+		if flag.aliasFor != "" || flag.source == fromArgs {
+			continue
+		}
+		err := fs.bindCfgErr(flag, keys(flag.cfgs)...)
+		if err != nil {
+			return err
+		}
 	}
+	return nil
 }
 
 // if you are using NewCmd(..) constructor then use the SubCmd(..) method rather than this or else
@@ -1530,12 +1785,31 @@ func (fs *FlagSet) SubCmdFs(name string, usage string, fn func(fs *FlagSet, args
 	subFs.cfgPath = fs.cfgPath
 	subFs.cfg = fs.cfg
 	subFs.parentCmd = fs
+	subFs.inheritCfgFlag()
 	fs.SubCmds[name] = &subCommand{
 		fn: fn,
 		fs: subFs,
 	}
 }
 
+// declares the flags and sub commands of the sub command name by calling declare right away
+// https://github.com/ondbyte/turbo_flag#sub-commands
+func SubCmdFlags(name string, declare func(cmd CMD)) {
+	CommandLine.SubCmdFlags(name, declare)
+}
+
+// declares the flags and sub commands of the sub command name by calling declare right away with the CMD the
+// sub command runs with, so the usage, completions, docs, schema and WriteCfg know them without running the sub command.
+// the callback of the sub command only parses, it must not define the declared flags again.
+// https://github.com/ondbyte/turbo_flag#sub-commands
+func (fs *FlagSet) SubCmdFlags(name string, declare func(cmd CMD)) {
+	sc, ok := fs.SubCmds[name]
+	if !ok {
+		panic(fmt.Sprintf("you are trying to declare the flags of the sub command %v but it doesn't exist", name))
+	}
+	declare(sc.fs)
+}
+
 // adds a new sub flagset to the parent flagset, loads the config file if it exists in the parent
 // the sub command fn recieves the new FlagSet and the arguments thats for the sub command
 // you can add new flags to this sub flagset and call fs.Parse with the arguments you recieved in this function
@@ -1553,6 +1827,7 @@ func (fs *FlagSet) SubCmd(name string, usage string, fn func(cmd CMD, args []str
 	subFs.cfgPath = fs.cfgPath
 	subFs.cfg = fs.cfg
 	subFs.parentCmd = fs
+	subFs.inheritCfgFlag()
 	fs.SubCmds[name] = &subCommand{
 		fn: func(fs *FlagSet, args []string) {
 			var c CMD
@@ -1659,14 +1934,23 @@ func (fs *FlagSet) Cfg(cfgs ...string) *flagFeature {
 }
 
 func (fs *FlagSet) bindCfg(to *Flag, cfgs ...string) {
+	err := fs.bindCfgErr(to, cfgs...)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// bindCfgErr is bindCfg returning the error of setting a value of the cfg to the flag
+func (fs *FlagSet) bindCfgErr(to *Flag, cfgs ...string) error {
 	for _, notation := range cfgs {
-		val, err := getValueByDotNotation(fs.cfg, notation)
-		if err == nil && val != "" {
-			err := to.Set(val)
-			if err != nil {
-				panic(fmt.Errorf("unable to set notation %v value %v to flag %v", notation, val, to.Name))
-			}
-		} else {
+		before, source := to.Value.String(), to.source
+		set, err := setFromCfg(to, fs.cfg, notation)
+		if err != nil {
+			return err
+		}
+		if set {
+			fs.markCfgSet(to, before, source)
+		} else if !hasWildcard(notation) {
 			cs, err := setValueByDotNotation(fs.cfg, notation, to.Value.String())
 			if err == nil {
 				for k, v := range cs {
@@ -1678,6 +1962,7 @@ func (fs *FlagSet) bindCfg(to *Flag, cfgs ...string) {
 	for _, cfg := range cfgs {
 		to.cfgs[cfg] = true
 	}
+	return nil
 }
 
 // binds env/s to the to flag you are defining
@@ -1706,8 +1991,169 @@ func (fs *FlagSet) bindEnv(to *Flag, envs ...string) {
 			if err != nil {
 				panic(fmt.Errorf("error while setting value from environment, flag name %v,env %v,value %v : %v", to.Name, env, val, err))
 			}
+			fs.markSet(to, fromEnv)
+		}
+	}
+}
+
+// markSet records where the value of flag came from, warns if the flag is deprecated
+// and forwards its value to the replacement flag
+func (fs *FlagSet) markSet(flag *Flag, source flagSource) {
+	flag = fs.primaryFlag(flag)
+	flag.source = source
+	if flag.deprecated == "" {
+		return
+	}
+	if flag.replacement != "" {
+		fs.printWarning(fs.msg(MsgDeprecatedUse, flag.Name, flag.deprecated, flag.replacement))
+	} else {
+		fs.printWarning(fs.msg(MsgDeprecated, flag.Name, flag.deprecated))
+	}
+	fs.forward(flag)
+}
+
+// setFromCfg sets flag to the value at the notation in cfg, it reports whether cfg has a value there
+func setFromCfg(flag *Flag, cfg map[string]interface{}, notation string) (bool, error) {
+	if hasWildcard(notation) {
+		// the flags collecting their values like a slice get all the values matched
+		vals, _ := getValuesByDotNotation(cfg, notation)
+		if len(vals) == 0 {
+			return false, nil
+		}
+		if slice, ok := flag.Value.(SliceValue); ok {
+			err := slice.Replace(vals)
+			if err != nil {
+				return true, fmt.Errorf("unable to set notation %v values %v to flag %v : %v", notation, vals, flag.Name, err)
+			}
+			return true, nil
+		}
+		for _, val := range vals {
+			err := flag.Set(val)
+			if err != nil {
+				return true, fmt.Errorf("unable to set notation %v value %v to flag %v : %v", notation, val, flag.Name, err)
+			}
+		}
+		return true, nil
+	}
+	val, err := getValueByDotNotation(cfg, notation)
+	if err != nil || val == "" {
+		return false, nil
+	}
+	err = flag.Set(val)
+	if err != nil {
+		return true, fmt.Errorf("unable to set notation %v value %v to flag %v : %v", notation, val, flag.Name, err)
+	}
+	return true, nil
+}
+
+// markCfgSet marks flag as set from a cfg unless it already was with the value before, so reloading
+// the cfg doesn't warn about the deprecated flags again or forward the values which didn't change
+func (fs *FlagSet) markCfgSet(flag *Flag, before string, source flagSource) {
+	if source == fromCfg && flag.Value.String() == before {
+		return
+	}
+	fs.markSet(flag, fromCfg)
+}
+
+// forward sets the value of the deprecated flag to its replacement, unless the replacement
+// has a value from a source with a higher precedence
+func (fs *FlagSet) forward(deprecated *Flag) {
+	replacement, ok := fs.formal[deprecated.replacement]
+	if !ok || replacement.source > deprecated.source {
+		return
+	}
+	err := replacement.Set(deprecated.Value.String())
+	if err != nil {
+		fs.printWarning(fs.msg(MsgForwardFailed, deprecated.Name, replacement.Name, err))
+		return
+	}
+	fs.markSet(replacement, deprecated.source)
+}
+
+// sets the writer the warnings of the default flagset are printed to
+// https://github.com/ondbyte/turbo_flag#hidden-and-deprecated-flags
+func SetWarningOutput(w io.Writer) {
+	CommandLine.SetWarningOutput(w)
+}
+
+// sets the writer the warnings of this command and its sub commands are printed to, like the
+// warnings about deprecated flags, by default it is stderr.
+// https://github.com/ondbyte/turbo_flag#hidden-and-deprecated-flags
+func (fs *FlagSet) SetWarningOutput(w io.Writer) {
+	fs.warnOutput = w
+}
+
+// warningOutput returns the writer set on this command or the closest parent, stderr otherwise
+func (fs *FlagSet) warningOutput() io.Writer {
+	for cmd := fs; cmd != nil; cmd = cmd.parentCmd {
+		if cmd.warnOutput != nil {
+			return cmd.warnOutput
 		}
 	}
+	return os.Stderr
+}
+
+// hides the flag you are defining from the usage of the default flagset
+// https://github.com/ondbyte/turbo_flag#hidden-and-deprecated-flags
+func Hidden() *flagFeature {
+	return CommandLine.Hidden()
+}
+
+// hides the flag you are defining from the usage, completions and docs, the flag still parses
+// https://github.com/ondbyte/turbo_flag#hidden-and-deprecated-flags
+func (fs *FlagSet) Hidden() *flagFeature {
+	return &flagFeature{
+		index: 14,
+		add: func(fs *FlagSet, f *Flag) {
+			f.hidden = true
+		},
+	}
+}
+
+// deprecates the flag you are defining in the default flagset
+// https://github.com/ondbyte/turbo_flag#hidden-and-deprecated-flags
+func Deprecated(msg string, replacement string) *flagFeature {
+	return CommandLine.Deprecated(msg, replacement)
+}
+
+// deprecates the flag you are defining, msg is printed as a warning when the flag is set from the args,
+// an env or a cfg. when replacement is not empty the value of the flag is forwarded to the flag named replacement.
+// https://github.com/ondbyte/turbo_flag#hidden-and-deprecated-flags
+func (fs *FlagSet) Deprecated(msg string, replacement string) *flagFeature {
+	return &flagFeature{
+		index: 5,
+		add: func(fs *FlagSet, f *Flag) {
+			if replacement == f.Name {
+				panic(fmt.Sprintf("flag %v cannot be the replacement of itself", f.Name))
+			}
+			// the values are forwarded along the replacements, a cycle would forward them forever
+			for next := fs.formal[replacement]; next != nil; next = fs.formal[next.replacement] {
+				if next.replacement == f.Name {
+					panic(fmt.Sprintf("flag %v cannot be replaced by %v, %v is deprecated in favour of %v", f.Name, replacement, next.Name, f.Name))
+				}
+			}
+			f.deprecated = msg
+			f.replacement = replacement
+		},
+	}
+}
+
+// marks the flag you are defining as required in the default flagset
+// https://github.com/ondbyte/turbo_flag#required-flags
+func Required() *flagFeature {
+	return CommandLine.Required()
+}
+
+// marks the flag you are defining as required, Parse returns an error when the flag
+// is not set from the arguments, a bound env or a bound cfg.
+// https://github.com/ondbyte/turbo_flag#required-flags
+func (fs *FlagSet) Required() *flagFeature {
+	return &flagFeature{
+		index: 13,
+		add: func(fs *FlagSet, f *Flag) {
+			f.required = true
+		},
+	}
 }
 
 func (fs *FlagSet) GetFlagForPtr(ptr interface{}) (*Flag, error) {
@@ -144,6 +144,32 @@ func TestNestedSubCmdFsRunsWithValidArgs(t *testing.T) {
 	}
 }
 
+func TestFlagSet_SubCmdFlags(t *testing.T) {
+	branch := ""
+	fs := NewFlagSet("git", ContinueOnError)
+	fs.SubCmdFs("commit", "", func(fs *FlagSet, args []string) {
+		err := fs.Parse(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+		branch = fs.Lookup("branch").Value.String()
+	})
+	fs.SubCmdFlags("commit", func(cmd CMD) {
+		cmd.String("branch", "main", "", cmd.Alias("b"))
+	})
+	schema := fs.Schema()
+	if len(schema.SubCmds) != 1 || len(schema.SubCmds[0].Flags) == 0 || branch != "" {
+		t.Fatalf("expected the declared flags without running commit, got %+v", schema.SubCmds)
+	}
+	err := fs.Parse([]string{"commit", "-b", "stable"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if branch != "stable" {
+		t.Errorf("expected the declared flag to be parsed, got %v", branch)
+	}
+}
+
 func TestFlagSet_BindEnv(t *testing.T) {
 	fs := NewFlagSet("test", ContinueOnError)
 	dir := t.TempDir()
@@ -186,7 +212,7 @@ func TestFlagSet_BindEnv(t *testing.T) {
 }
 
 func TestFlagSet_BindCfg(t *testing.T) {
-	for _, ext := range []string{"json", "yaml", "yml", "toml"} {
+	for _, ext := range []string{"json", "yaml", "yml", "toml", "ini", "properties", "hcl"} {
 		defer func() {
 			err := recover()
 			if err != nil {
@@ -230,6 +256,91 @@ func TestFlagSet_BindCfg(t *testing.T) {
 	}
 }
 
+func TestFlagSet_Required(t *testing.T) {
+	newFs := func() *FlagSet {
+		fs := NewFlagSet("test", ContinueOnError)
+		fs.String("token", "", "", fs.Required(), fs.Alias("t"), fs.Env("TEST_REQUIRED_TOKEN"))
+		fs.String("user", "", "", fs.Required(), fs.Cfg("database.user"))
+		return fs
+	}
+	err := newFs().Parse(nil)
+	if err == nil || err.Error() != "required flag/s -token, -user not set" {
+		t.Fatalf("expected the required flags to be reported, got %v", err)
+	}
+	err = newFs().Parse([]string{"-t", "abc", "--user", "yadu"})
+	if err != nil {
+		t.Fatalf("expected the required flags set by args to parse : %v", err)
+	}
+	os.Setenv("TEST_REQUIRED_TOKEN", "abc")
+	defer os.Unsetenv("TEST_REQUIRED_TOKEN")
+	err = newFs().Parse([]string{"--user", "yadu"})
+	if err != nil {
+		t.Fatalf("expected the required flag set by env to parse : %v", err)
+	}
+	fs := NewFlagSet("test", ContinueOnError)
+	err = fs.LoadCfg("./test_config/demo.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fs.String("password", "", "", fs.Required(), fs.Cfg("database.password"))
+	err = fs.Parse(nil)
+	if err != nil {
+		t.Fatalf("expected the required flag set by cfg to parse : %v", err)
+	}
+}
+
+func TestFlagSet_Deprecated(t *testing.T) {
+	warnings := &bytes.Buffer{}
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.SetWarningOutput(warnings)
+	old := fs.String("addr", "", "", fs.Deprecated("it is split into host and port", "host"), fs.Alias("a"))
+	legacy := fs.Bool("legacy", false, "", fs.Deprecated("it does nothing", ""))
+	host := fs.String("host", "localhost", "")
+	err := fs.Parse([]string{"-a", "example.com", "--legacy"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *old != "example.com" || *host != "example.com" || !*legacy {
+		t.Fatalf("expected the value to be forwarded to host, got addr=%v host=%v legacy=%v", *old, *host, *legacy)
+	}
+	want := "warning: flag -addr is deprecated, it is split into host and port, use -host instead\n" +
+		"warning: flag -legacy is deprecated, it does nothing\n"
+	if warnings.String() != want {
+		t.Fatalf("expected the warnings\n%vgot\n%v", want, warnings.String())
+	}
+
+	// the replacement set from the args wins over the deprecated flag set from the env
+	os.Setenv("TEST_DEPRECATED_ADDR", "from-env")
+	defer os.Unsetenv("TEST_DEPRECATED_ADDR")
+	warnings.Reset()
+	fs = NewFlagSet("test", ContinueOnError)
+	fs.SetWarningOutput(warnings)
+	fs.String("addr", "", "", fs.Deprecated("use host", "host"), fs.Env("TEST_DEPRECATED_ADDR"))
+	host = fs.String("host", "localhost", "")
+	if *host != "from-env" || warnings.Len() == 0 {
+		t.Fatalf("expected the env value to be forwarded with a warning, got %v", *host)
+	}
+	err = fs.Parse([]string{"--host", "from-args"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *host != "from-args" {
+		t.Fatalf("expected host from the args, got %v", *host)
+	}
+}
+
+func TestFlagSet_DeprecatedCycle(t *testing.T) {
+	fs := NewFlagSet("test", ContinueOnError)
+	fs.String("addr", "", "", fs.Deprecated("use host", "host"))
+	fs.String("host", "", "", fs.Deprecated("use endpoint", "endpoint"))
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for the cycle of replacements")
+		}
+	}()
+	fs.String("endpoint", "", "", fs.Deprecated("use addr", "addr"))
+}
+
 func TestFlagSet_Alias(t *testing.T) {
 	fs := NewFlagSet("test", ContinueOnError)
 	password := fs.String("password", "", "", fs.Alias("p"))
@@ -5,6 +5,7 @@ go 1.18
 require (
 	github.com/BurntSushi/toml v1.3.2
 	github.com/go-yaml/yaml v2.1.0+incompatible
+	github.com/hashicorp/hcl v1.0.0
 )
 
 require (
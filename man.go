@@ -0,0 +1,162 @@
+package flag
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ManHeader is the header of the man pages written by GenManPages
+type ManHeader struct {
+	Section string // section of the manual, "1" when empty
+	Date    string // date shown in the footer, like "January 2024"
+	Source  string // source of the program shown in the footer, like "tool 1.2.0"
+	Manual  string // title of the manual shown in the header, like "Tool Manual"
+}
+
+// roffEscape escapes s to be used as text in a roff document
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\e`)
+	s = strings.ReplaceAll(s, "-", `\-`)
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		// lines starting with a . or ' are requests to roff
+		if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+			lines[i] = `\&` + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GenManPages writes the man pages of the default flagset and its sub commands to the directory dir
+// https://github.com/ondbyte/turbo_flag#man-pages
+func GenManPages(dir string, header ManHeader) error {
+	return CommandLine.GenManPages(dir, header)
+}
+
+// GenManPages writes a roff man page for this command and each of its sub commands to the directory dir,
+// the pages are named after the command path like "tool-remote-add.1", the pages of the sub commands
+// list only the flags and sub commands declared using SubCmdFlags.
+// https://github.com/ondbyte/turbo_flag#man-pages
+func (fs *FlagSet) GenManPages(dir string, header ManHeader) error {
+	if header.Section == "" {
+		header.Section = "1"
+	}
+	var err error
+	newDocCmd(fs).walk(func(c *docCmd) {
+		if err != nil {
+			return
+		}
+		path := filepath.Join(dir, docName(c.fs)+"."+header.Section)
+		var file *os.File
+		file, err = os.Create(path)
+		if err != nil {
+			err = fmt.Errorf("unable to create the man page %v : %v", path, err)
+			return
+		}
+		err = genManPage(file, c, header)
+		if closeErr := file.Close(); err == nil && closeErr != nil {
+			err = fmt.Errorf("unable to write the man page %v : %v", path, closeErr)
+		}
+	})
+	return err
+}
+
+// GenManPage writes the roff man page of the default flagset to w
+// https://github.com/ondbyte/turbo_flag#man-pages
+func GenManPage(w io.Writer, header ManHeader) error {
+	return CommandLine.GenManPage(w, header)
+}
+
+// GenManPage writes the roff man page of this command to w
+// https://github.com/ondbyte/turbo_flag#man-pages
+func (fs *FlagSet) GenManPage(w io.Writer, header ManHeader) error {
+	if header.Section == "" {
+		header.Section = "1"
+	}
+	return genManPage(w, newDocCmd(fs), header)
+}
+
+func genManPage(w io.Writer, c *docCmd, header ManHeader) error {
+	data := c.fs.UsageData(true)
+	path := strings.Join(docPath(c.fs), " ")
+	b := &strings.Builder{}
+	fmt.Fprintf(b, ".TH %q %q %q %q %q\n", strings.ToUpper(docName(c.fs)), header.Section, header.Date, header.Source, header.Manual)
+	b.WriteString(".SH NAME\n")
+	if data.Description != "" {
+		fmt.Fprintf(b, "%v \\- %v\n", roffEscape(docName(c.fs)), roffEscape(strings.SplitN(data.Description, "\n", 2)[0]))
+	} else {
+		fmt.Fprintf(b, "%v\n", roffEscape(docName(c.fs)))
+	}
+
+	b.WriteString(".SH SYNOPSIS\n")
+	if len(data.Flags) > 0 || len(data.SubCmds) == 0 {
+		fmt.Fprintf(b, ".B %v\n[<flags>]\n", roffEscape(path))
+	}
+	if len(data.Flags) > 0 && len(data.SubCmds) > 0 {
+		b.WriteString(".br\n")
+	}
+	if len(data.SubCmds) > 0 {
+		fmt.Fprintf(b, ".B %v\n[<sub\\-command>]\n", roffEscape(path))
+	}
+
+	if data.Description != "" {
+		fmt.Fprintf(b, ".SH DESCRIPTION\n%v\n", roffEscape(data.Description))
+	}
+
+	if len(data.SubCmdGroups) > 0 {
+		b.WriteString(".SH COMMANDS\n")
+	}
+	for _, group := range data.SubCmdGroups {
+		if group.Title != "" {
+			fmt.Fprintf(b, ".SS %v\n", roffEscape(group.Title))
+		}
+		for _, sc := range group.SubCmds {
+			fmt.Fprintf(b, ".TP\n.B %v\n%v\n", roffEscape(sc.Name), roffEscape(sc.Usage))
+		}
+	}
+
+	if len(data.FlagGroups) > 0 {
+		b.WriteString(".SH OPTIONS\n")
+	}
+	for _, group := range data.FlagGroups {
+		if group.Title != "" {
+			fmt.Fprintf(b, ".SS %v\n", roffEscape(group.Title))
+		}
+		for _, flag := range group.Flags {
+			fmt.Fprintf(b, ".TP\n.B %v\n%v\n", roffEscape(strings.TrimSpace(flag.Names)), roffEscape(styledFlagUsage(Style{}, true, flag)))
+		}
+	}
+
+	if len(data.Examples) > 0 {
+		b.WriteString(".SH EXAMPLES\n")
+	}
+	for _, example := range data.Examples {
+		if example.Description != "" {
+			fmt.Fprintf(b, ".PP\n%v\n", roffEscape(example.Description))
+		}
+		fmt.Fprintf(b, ".PP\n.RS\n.nf\n%v\n.fi\n.RE\n", roffEscape(example.Command))
+	}
+
+	var seeAlso []string
+	if c.fs.parentCmd != nil {
+		seeAlso = append(seeAlso, docName(c.fs.parentCmd))
+	}
+	for _, child := range c.children {
+		seeAlso = append(seeAlso, docName(child.fs))
+	}
+	if len(seeAlso) > 0 {
+		b.WriteString(".SH SEE ALSO\n")
+		for i, name := range seeAlso {
+			fmt.Fprintf(b, ".BR %v (%v)", roffEscape(name), header.Section)
+			if i < len(seeAlso)-1 {
+				b.WriteString(",")
+			}
+			b.WriteString("\n")
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
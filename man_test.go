@@ -0,0 +1,51 @@
+package flag_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+// compareGoldenDir compares the files generated in dir with the ones in golden, updating them with -update
+func compareGoldenDir(t *testing.T, dir string, golden string, count int) {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != count {
+		t.Fatalf("expected %v files, got %v", count, files)
+	}
+	for _, file := range files {
+		got, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		goldenFile := filepath.Join(golden, filepath.Base(file))
+		if *update {
+			err = os.WriteFile(goldenFile, got, 0644)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		want, err := os.ReadFile(goldenFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%v differs from %v, got\n%s", filepath.Base(file), goldenFile, got)
+		}
+	}
+}
+
+func TestFlagSet_GenManPages(t *testing.T) {
+	dir := t.TempDir()
+	err := newCompletionTool().GenManPages(dir, ManHeader{Date: "January 2024", Source: "tool 1.0.0", Manual: "Tool Manual"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	compareGoldenDir(t, dir, filepath.Join("testdata", "man"), 4)
+}
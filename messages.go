@@ -0,0 +1,200 @@
+package flag
+
+import (
+	"errors"
+	"fmt"
+)
+
+// keys of the user facing messages, see EnglishFormats for the arguments each of them is formatted with
+const (
+	MsgBadFlagSyntax        = "bad_flag_syntax"
+	MsgFlagNotDefined       = "flag_not_defined"
+	MsgInvalidBoolValue     = "invalid_bool_value"
+	MsgInvalidBoolFlag      = "invalid_bool_flag"
+	MsgFlagNeedsArgument    = "flag_needs_argument"
+	MsgInvalidValue         = "invalid_value"
+	MsgInvalidEnum          = "invalid_enum"
+	MsgNoSuchFlag           = "no_such_flag"
+	MsgRequiredNotSet       = "required_not_set"
+	MsgSubCmdNotFound       = "sub_cmd_not_found"
+	MsgDeprecated           = "deprecated"
+	MsgDeprecatedUse        = "deprecated_use"
+	MsgForwardFailed        = "forward_failed"
+	MsgError                = "error"
+	MsgWarning              = "warning"
+	MsgUsage                = "usage"
+	MsgOr                   = "or"
+	MsgSubCmds              = "sub_cmds"
+	MsgPlugins              = "plugins"
+	MsgFlags                = "flags"
+	MsgExamples             = "examples"
+	MsgMoreInfo             = "more_info"
+	MsgUsageNotAvailable    = "usage_not_available"
+	MsgDefaultsTo           = "defaults_to"
+	MsgPossibleValues       = "possible_values"
+	MsgBindsToEnvs          = "binds_to_envs"
+	MsgBindsToCfgs          = "binds_to_cfgs"
+	MsgRequired             = "required"
+	MsgDeprecatedInUsage    = "deprecated_in_usage"
+	MsgDeprecatedUseInUsage = "deprecated_use_in_usage"
+	MsgCompletionArgs       = "completion_args"
+	MsgUnsupportedShell     = "unsupported_shell"
+	MsgCfgFlagUsage         = "cfg_flag_usage"
+	MsgPluginFailed         = "plugin_failed"
+	MsgCfgReadFailed        = "cfg_read_failed"
+	MsgCfgFileReadFailed    = "cfg_file_read_failed"
+	MsgCfgFormatEmpty       = "cfg_format_empty"
+	MsgCfgNotFound          = "cfg_not_found"
+	MsgCfgNoWatch           = "cfg_no_watch"
+	MsgCfgStdinWatch        = "cfg_stdin_watch"
+	MsgCfgReloadFailed      = "cfg_reload_failed"
+)
+
+// EnglishFormats are the formats of the messages in English, the other catalogs fall back to these
+var EnglishFormats = map[string]string{
+	MsgBadFlagSyntax:        "bad flag syntax: %v",                                                // the argument
+	MsgFlagNotDefined:       "flag provided but not defined: -%v",                                 // name of the flag
+	MsgInvalidBoolValue:     "invalid boolean value %q for -%v: %v",                               // value, name of the flag, error
+	MsgInvalidBoolFlag:      "invalid boolean flag %v: %v",                                        // name of the flag, error
+	MsgFlagNeedsArgument:    "flag needs an argument: -%v",                                        // name of the flag
+	MsgInvalidValue:         "invalid value %q for flag -%v: %v",                                  // value, name of the flag, error
+	MsgInvalidEnum:          "flag %v is a enum flag, needs one of these values %v",               // name of the flag, possible values
+	MsgNoSuchFlag:           "no such flag -%v",                                                   // name of the flag
+	MsgRequiredNotSet:       "required flag/s %v not set",                                         // the flags
+	MsgSubCmdNotFound:       "you are trying to run subcommand with name %v but it doesn't exist", // name of the sub command
+	MsgDeprecated:           "flag -%v is deprecated, %v",                                         // name of the flag, why it is deprecated
+	MsgDeprecatedUse:        "flag -%v is deprecated, %v, use -%v instead",                        // name of the flag, why it is deprecated, replacement
+	MsgForwardFailed:        "unable to forward the value of flag -%v to flag -%v : %v",           // name of the flag, replacement, error
+	MsgError:                "error: %v",                                                          // the error
+	MsgWarning:              "warning: %v",                                                        // the warning
+	MsgUsage:                "usage",
+	MsgOr:                   "or",
+	MsgSubCmds:              "Available sub commands",
+	MsgPlugins:              "Available plugins",
+	MsgFlags:                "Flags",
+	MsgExamples:             "Examples",
+	MsgMoreInfo:             "Use \"%v [command] --help\" for more information about a command.", // path of the command
+	MsgUsageNotAvailable:    "usage not available",
+	MsgDefaultsTo:           "defaults to %v",       // the quoted default value
+	MsgPossibleValues:       "possible values [%v]", // the quoted values
+	MsgBindsToEnvs:          "binds to env/s [%v]",  // the quoted envs
+	MsgBindsToCfgs:          "binds to cfg/s [%v]",  // the quoted cfgs
+	MsgRequired:             "required",
+	MsgDeprecatedInUsage:    "deprecated, %v",                                  // why it is deprecated
+	MsgDeprecatedUseInUsage: "deprecated, %v, use --%v instead",                // why it is deprecated, replacement
+	MsgCompletionArgs:       "completion needs one argument, one of [%v]",      // the shells
+	MsgUnsupportedShell:     "unsupported shell %v, supported shells are [%v]", // the shell, the supported shells
+	MsgCfgFlagUsage:         "path of the config file to load",
+	MsgPluginFailed:         "unable to run plugin %v at %v : %v",    // name of the plugin, its path, error
+	MsgCfgReadFailed:        "failed to read config : %v",            // error
+	MsgCfgFileReadFailed:    "failed to read config file at %v : %v", // path of the file, error
+	MsgCfgFormatEmpty:       "format is empty while loading config, pass one or set it using SetCfgFormat",
+	MsgCfgNotFound:          "no config file named %v found, searched [%v]", // name of the file, the places searched
+	MsgCfgNoWatch:           "no config files loaded to watch",
+	MsgCfgStdinWatch:        "config read from stdin can't be watched",
+	MsgCfgReloadFailed:      "unable to reload config : %v", // error
+}
+
+// Catalog provides the user facing messages of a FlagSet in a locale
+type Catalog interface {
+	// Locale returns the locale of the messages like "de", it picks the usages set using LocalUsage
+	Locale() string
+	// Message returns the message for the key (one of the Msg constants) formatted with args
+	Message(key string, args ...interface{}) string
+}
+
+// Messages is a Catalog of formats keyed by the Msg constants, the keys missing in Formats fall back to EnglishFormats.
+// the errors the messages wrap, like the ones of the files, the formats and the dot notations ("invalid notation",
+// "value not found for dot notation"), are in English.
+type Messages struct {
+	Lang    string
+	Formats map[string]string
+}
+
+// English is the default catalog
+var English = Messages{Lang: "en", Formats: EnglishFormats}
+
+// Locale returns Lang
+func (m Messages) Locale() string {
+	return m.Lang
+}
+
+// Message formats the format of the key with args
+func (m Messages) Message(key string, args ...interface{}) string {
+	format, ok := m.Formats[key]
+	if !ok {
+		format, ok = EnglishFormats[key]
+	}
+	if !ok {
+		format = key
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// sets the catalog the messages of the default flagset are taken from
+// https://github.com/ondbyte/turbo_flag#localization
+func SetCatalog(catalog Catalog) {
+	CommandLine.SetCatalog(catalog)
+}
+
+// sets the catalog the usage, errors and warnings of this command and its sub commands are taken from,
+// by default it is English.
+// https://github.com/ondbyte/turbo_flag#localization
+func (fs *FlagSet) SetCatalog(catalog Catalog) {
+	fs.messages = catalog
+}
+
+// catalog returns the catalog set on this command or the closest parent, English otherwise
+func (fs *FlagSet) catalog() Catalog {
+	for cmd := fs; cmd != nil; cmd = cmd.parentCmd {
+		if cmd.messages != nil {
+			return cmd.messages
+		}
+	}
+	return English
+}
+
+// msg returns the message for the key from the catalog of this command
+func (fs *FlagSet) msg(key string, args ...interface{}) string {
+	return fs.catalog().Message(key, args...)
+}
+
+// msgErr returns the message for the key from the catalog of this command as an error
+func (fs *FlagSet) msgErr(key string, args ...interface{}) error {
+	return errors.New(fs.msg(key, args...))
+}
+
+// sets the usage of the flag you are defining in the default flagset for a locale
+// https://github.com/ondbyte/turbo_flag#localization
+func LocalUsage(locale string, usage string) *flagFeature {
+	return CommandLine.LocalUsage(locale, usage)
+}
+
+// sets the usage of the flag you are defining for a locale, it is shown instead of the usage
+// when the locale of the catalog of the command is locale.
+// https://github.com/ondbyte/turbo_flag#localization
+func (fs *FlagSet) LocalUsage(locale string, usage string) *flagFeature {
+	return &flagFeature{
+		index: 15,
+		add: func(fs *FlagSet, f *Flag) {
+			if f.usages == nil {
+				f.usages = make(map[string]string)
+			}
+			f.usages[locale] = usage
+		},
+	}
+}
+
+// localUsage returns the usage of the flag for the locale of the catalog of fs
+func (fs *FlagSet) localUsage(flag *Flag) string {
+	if usage, ok := flag.usages[fs.catalog().Locale()]; ok {
+		return usage
+	}
+	if flag.usageKey != "" {
+		return fs.msg(flag.usageKey)
+	}
+	return flag.Usage
+}
@@ -0,0 +1,108 @@
+package flag_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+var german = Messages{Lang: "de", Formats: map[string]string{
+	MsgFlagNotDefined: "Flag angegeben, aber nicht definiert: -%v",
+	MsgInvalidEnum:    "Flag %v erlaubt nur die Werte %v",
+	MsgInvalidValue:   "ungültiger Wert %q für Flag -%v: %v",
+	MsgUsage:          "Verwendung",
+	MsgOr:             "oder",
+	MsgSubCmds:        "Verfügbare Unterbefehle",
+	MsgFlags:          "Optionen",
+	MsgDefaultsTo:     "Standard %v",
+	MsgMoreInfo:       "\"%v [Befehl] --help\" zeigt mehr über einen Befehl.",
+}}
+
+func TestFlagSet_SetCatalog(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetUsageWidth(200)
+	fs.SetCatalog(german)
+	fs.String("mode", "a", "the mode", fs.LocalUsage("de", "der Modus"), fs.Enum("a", "b"))
+	fs.Bool("verbose", false, "prints more")
+	var subErr error
+	fs.SubCmdFs("commit", "commits the changes", func(fs *FlagSet, args []string) {
+		subErr = fs.Parse(args)
+	})
+	usage, err := fs.GetDefaultUsageLong()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Verwendung:
+  tool [<flags>]
+  oder
+  tool [<sub-command>]
+
+Verfügbare Unterbefehle:
+  commit  commits the changes
+
+Optionen:
+  --mode string  der Modus (Standard "a", possible values ["a", "b"])
+  --verbose      prints more (Standard "false")
+
+"tool [Befehl] --help" zeigt mehr über einen Befehl.
+`
+	if usage != want {
+		t.Errorf("GetDefaultUsageLong() =\n%v\nwant\n%v", usage, want)
+	}
+
+	err = fs.Parse([]string{"--mode", "c"})
+	if err == nil || err.Error() != `ungültiger Wert "c" für Flag -mode: Flag mode erlaubt nur die Werte a, b` {
+		t.Errorf("expected the localized error, got %v", err)
+	}
+	err = fs.Parse([]string{"commit", "--unknown"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subErr == nil || subErr.Error() != "Flag angegeben, aber nicht definiert: -unknown" {
+		t.Errorf("expected the sub command to use the catalog of its parent, got %v", subErr)
+	}
+}
+
+func TestFlagSet_SetCatalogTemplates(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetCatalog(Messages{Lang: "de", Formats: map[string]string{
+		MsgDefaultsTo:       "Standard %v",
+		MsgCfgFlagUsage:     "Pfad der Konfigurationsdatei",
+		MsgUnsupportedShell: "Shell %v wird nicht unterstützt, nur [%v]",
+	}})
+	fs.EnableCfgFlag("config")
+	err := fs.SetUsageTemplate(`{{range .Flags}}{{flagUsage false .}}|{{$.Style.FlagUsage false .}}{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage, err := fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `Pfad der Konfigurationsdatei (Standard "")|Pfad der Konfigurationsdatei (Standard "")`
+	if usage != want {
+		t.Errorf("expected the template funcs to use the catalog, got %v", usage)
+	}
+	err = fs.GenCompletion(&strings.Builder{}, "tcsh")
+	if err == nil || err.Error() != "Shell tcsh wird nicht unterstützt, nur [bash, zsh, fish, powershell]" {
+		t.Errorf("expected the localized error, got %v", err)
+	}
+}
+
+func TestFlagSet_SetCatalogCfgErrors(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetCatalog(Messages{Lang: "de", Formats: map[string]string{
+		MsgCfgNotFound: "keine Konfiguration %v gefunden, gesucht in [%v]",
+		MsgCfgNoWatch:  "keine Konfiguration zum Beobachten geladen",
+	}})
+	_, err := fs.FindCfg("tool", t.TempDir())
+	if err == nil || !strings.HasPrefix(err.Error(), "keine Konfiguration tool gefunden") {
+		t.Errorf("expected the localized error, got %v", err)
+	}
+	err = fs.WatchCfg(context.Background(), func([]string, error) {})
+	if err == nil || err.Error() != "keine Konfiguration zum Beobachten geladen" {
+		t.Errorf("expected the localized error, got %v", err)
+	}
+}
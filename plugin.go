@@ -0,0 +1,186 @@
+package flag
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type pluginCfg struct {
+	dirs []string // directories to look for plugins in, PATH is used when empty
+}
+
+// PluginExitError is returned while parsing when a plugin executable ran but exited with a non zero code.
+// with ExitOnError the program exits with the same code.
+type PluginExitError struct {
+	Name string // name of the plugin as typed on the command line
+	Path string // path of the executable which ran
+	Code int    // exit code of the plugin
+}
+
+func (e *PluginExitError) Error() string {
+	return fmt.Sprintf("plugin %v (%v) exited with code %v", e.Name, e.Path, e.Code)
+}
+
+// turns on git style plugin discovery for the default flagset
+// https://github.com/ondbyte/turbo_flag#plugins
+func EnablePlugins(dirs ...string) {
+	CommandLine.EnablePlugins(dirs...)
+}
+
+// turns on git style plugin discovery for this command and its sub commands,
+// when a sub command is not defined an executable named <root>-<name> (<root>-<sub>-<name> for the sub commands)
+// is looked up in the dirs or in PATH when no dirs are passed, it is run with the remaining arguments and
+// the values of the flags of this command and its parent commands are forwarded as env
+// variables named <ROOT>_<FLAG>.
+// https://github.com/ondbyte/turbo_flag#plugins
+func (fs *FlagSet) EnablePlugins(dirs ...string) {
+	fs.plugins = &pluginCfg{dirs: dirs}
+}
+
+// returns the plugin config this command should use, its own or the closest parent's
+func (fs *FlagSet) pluginCfg() *pluginCfg {
+	for cmd := fs; cmd != nil; cmd = cmd.parentCmd {
+		if cmd.plugins != nil {
+			return cmd.plugins
+		}
+	}
+	return nil
+}
+
+func (fs *FlagSet) pluginPrefix() string {
+	path := fs.commandPath()
+	path[0] = filepath.Base(path[0])
+	return strings.Join(path, "-") + "-"
+}
+
+func (fs *FlagSet) pluginDirs(cfg *pluginCfg) []string {
+	if len(cfg.dirs) > 0 {
+		return cfg.dirs
+	}
+	return filepath.SplitList(os.Getenv("PATH"))
+}
+
+// Plugins returns the sorted names of the plugins found for this command,
+// names of the sub commands already defined are left out as they take precedence.
+func (fs *FlagSet) Plugins() []string {
+	cfg := fs.pluginCfg()
+	if cfg == nil {
+		return nil
+	}
+	prefix := fs.pluginPrefix()
+	found := make(map[string]bool)
+	for _, dir := range fs.pluginDirs(cfg) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+				continue
+			}
+			info, err := entry.Info()
+			if err != nil || !isExecutable(info) {
+				continue
+			}
+			name := strings.TrimPrefix(entry.Name(), prefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if _, ok := fs.SubCmds[name]; ok || !validPluginName(name) {
+				continue
+			}
+			found[name] = true
+		}
+	}
+	plugins := keys(found)
+	sort.Strings(plugins)
+	return plugins
+}
+
+func isExecutable(info os.FileInfo) bool {
+	if runtime.GOOS == "windows" {
+		switch strings.ToLower(filepath.Ext(info.Name())) {
+		case ".exe", ".bat", ".cmd", ".com":
+			return true
+		}
+		return false
+	}
+	return info.Mode().IsRegular() && info.Mode().Perm()&0111 != 0
+}
+
+// lookupPlugin returns the path of the executable for the plugin with name if plugins are enabled
+func (fs *FlagSet) lookupPlugin(name string) (string, bool) {
+	cfg := fs.pluginCfg()
+	if cfg == nil || !validPluginName(name) {
+		return "", false
+	}
+	prefix := fs.pluginPrefix()
+	for _, dir := range fs.pluginDirs(cfg) {
+		if dir == "" {
+			continue
+		}
+		path, err := exec.LookPath(filepath.Join(dir, prefix+name))
+		if err == nil {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+// validPluginName reports whether name can be the name of a plugin, the names with a path separator
+// or ".." could run an executable outside of the plugin directories
+func validPluginName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, `/\`) && !strings.Contains(name, "..")
+}
+
+// pluginEnv returns the values of flags of this command and its parents as env variables,
+// the flags of the sub commands win over the ones of their parents with the same name
+func (fs *FlagSet) pluginEnv() []string {
+	root := fs.commandPath()[0]
+	var env []string
+	emitted := make(map[string]bool)
+	for cmd := fs; cmd != nil; cmd = cmd.parentCmd {
+		for _, flag := range sortFlags(cmd.formal) {
+			name := pluginEnvName(root, flag.Name)
+			if flag.aliasFor != "" || emitted[name] {
+				continue
+			}
+			emitted[name] = true
+			env = append(env, name+"="+flag.Value.String())
+		}
+	}
+	return env
+}
+
+func pluginEnvName(root string, flag string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, filepath.Base(root)+"_"+flag)
+}
+
+func (fs *FlagSet) runPlugin(name string, path string, args []string) error {
+	cmd := exec.Command(path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), fs.pluginEnv()...)
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return &PluginExitError{Name: name, Path: path, Code: exitErr.ExitCode()}
+	}
+	if err != nil {
+		return fs.msgErr(MsgPluginFailed, name, path, err)
+	}
+	return nil
+}
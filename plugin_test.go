@@ -0,0 +1,143 @@
+package flag_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func writePlugin(t *testing.T, dir string, name string, script string) {
+	t.Helper()
+	err := os.WriteFile(filepath.Join(dir, name), []byte("#!/bin/sh\n"+script+"\n"), 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFlagSet_Plugins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts need a posix shell")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	writePlugin(t, dir, "tool-hello", `echo "$@ $TOOL_REGION" > `+out)
+	writePlugin(t, dir, "tool-fail", "exit 3")
+	writePlugin(t, dir, "tool-commit", "exit 0")
+	err := os.WriteFile(filepath.Join(dir, "tool-notexec"), []byte(""), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.EnablePlugins(dir)
+	fs.String("region", "eu", "")
+	fs.SubCmdFs("commit", "", func(fs *FlagSet, args []string) {})
+
+	if got, want := fs.Plugins(), []string{"fail", "hello"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Plugins() = %v, want %v", got, want)
+	}
+	usage, err := fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(usage, "Available plugins:\n  fail\n  hello\n") {
+		t.Fatalf("usage should list plugins, got\n%v", usage)
+	}
+
+	err = fs.Parse([]string{"hello", "a", "--b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "a --b eu\n" {
+		t.Fatalf("plugin received %q", string(b))
+	}
+
+	err = fs.Parse([]string{"fail"})
+	var exitErr *PluginExitError
+	if !errors.As(err, &exitErr) || exitErr.Code != 3 {
+		t.Fatalf("expected plugin exit code 3, got %v", err)
+	}
+
+	err = fs.Parse([]string{"notexec"})
+	if err == nil {
+		t.Fatal("expected error for a non executable plugin")
+	}
+}
+
+func TestFlagSet_PluginsDisabled(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	if plugins := fs.Plugins(); len(plugins) != 0 {
+		t.Fatalf("expected no plugins, got %v", plugins)
+	}
+	err := fs.Parse([]string{"hello"})
+	if err == nil {
+		t.Fatal("expected error for an undefined sub command")
+	}
+}
+
+func TestFlagSet_PluginsPathTraversal(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts need a posix shell")
+	}
+	dir := t.TempDir()
+	pluginDir := filepath.Join(dir, "plugins")
+	err := os.Mkdir(pluginDir, 0755)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := filepath.Join(dir, "out")
+	writePlugin(t, dir, "evil", "touch "+out)
+
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.EnablePlugins(pluginDir)
+	for _, name := range []string{"/../evil", "../evil", `x\..\evil`} {
+		err = fs.Parse([]string{name})
+		if err == nil {
+			t.Errorf("expected the plugin name %v to be rejected", name)
+		}
+	}
+	if _, err := os.Stat(out); err == nil {
+		t.Fatal("expected no executable outside of the plugin directories to run")
+	}
+}
+
+func TestFlagSet_PluginEnvSubCmdWins(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugin scripts need a posix shell")
+	}
+	dir := t.TempDir()
+	out := filepath.Join(dir, "out")
+	writePlugin(t, dir, "tool-cloud-hello", `echo "$TOOL_REGION" > `+out)
+
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.EnablePlugins(dir)
+	fs.String("region", "eu", "")
+	fs.SubCmdFs("cloud", "", func(fs *FlagSet, args []string) {
+		fs.String("region", "us", "")
+		err := fs.Parse(args)
+		if err != nil {
+			t.Fatal(err)
+		}
+	})
+	err := fs.Parse([]string{"cloud", "hello"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "us\n" {
+		t.Fatalf("expected the flag of the sub command to win, plugin received %q", string(b))
+	}
+}
@@ -0,0 +1,107 @@
+package flag
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+)
+
+// Schema is a serializable description of a command and its sub commands,
+// encode two versions of it to find the breaking changes in a CLI between releases.
+type Schema struct {
+	Name    string       `json:"name"`
+	Usage   string       `json:"usage,omitempty"`
+	Hidden  bool         `json:"hidden,omitempty"`
+	Flags   []SchemaFlag `json:"flags,omitempty"`
+	SubCmds []Schema     `json:"sub_commands,omitempty"`
+}
+
+// SchemaFlag is a flag in Schema
+type SchemaFlag struct {
+	Name        string   `json:"name"`
+	Usage       string   `json:"usage,omitempty"`
+	Type        string   `json:"type"`
+	Default     string   `json:"default"`
+	Enums       []string `json:"enums,omitempty"`
+	Aliases     []string `json:"aliases,omitempty"`
+	Envs        []string `json:"envs,omitempty"`
+	Cfgs        []string `json:"cfgs,omitempty"`
+	Required    bool     `json:"required,omitempty"`
+	Hidden      bool     `json:"hidden,omitempty"`
+	Deprecated  string   `json:"deprecated,omitempty"`
+	Replacement string   `json:"replacement,omitempty"`
+}
+
+// returns a serializable description of the default flagset and its sub commands
+// https://github.com/ondbyte/turbo_flag#schema
+func GetSchema() Schema {
+	return CommandLine.Schema()
+}
+
+// Schema returns a serializable description of this command and its sub commands, hidden ones included,
+// the flags and sub commands are sorted by name so the schema of the same CLI is always the same.
+// a sub command has the flags and sub commands declared using SubCmdFlags, or defined when it ran.
+// https://github.com/ondbyte/turbo_flag#schema
+func (fs *FlagSet) Schema() Schema {
+	schema := Schema{Name: filepath.Base(fs.name), Usage: fs.usg}
+	for _, flag := range sortFlags(fs.formal) {
+		if flag.aliasFor != "" {
+			continue
+		}
+		typeName := valueTypeName(flag.Value)
+		if typeName == "" {
+			typeName = "bool"
+		}
+		schema.Flags = append(schema.Flags, SchemaFlag{
+			Name:        flag.Name,
+			Usage:       flag.Usage,
+			Type:        typeName,
+			Default:     flag.DefValue,
+			Enums:       sortedKeys(flag.enums),
+			Aliases:     sortedKeys(flag.alias),
+			Envs:        sortedKeys(flag.envs),
+			Cfgs:        sortedKeys(flag.cfgs),
+			Required:    flag.required,
+			Hidden:      flag.hidden,
+			Deprecated:  flag.deprecated,
+			Replacement: flag.replacement,
+		})
+	}
+	for _, name := range subCmdNames(fs) {
+		sc := fs.SubCmds[name]
+		sub := sc.fs.Schema()
+		sub.Hidden = sc.hidden
+		schema.SubCmds = append(schema.SubCmds, sub)
+	}
+	return schema
+}
+
+// subCmdNames returns the names of all the sub commands of fs sorted
+func subCmdNames(fs *FlagSet) []string {
+	names := make([]string, 0, len(fs.SubCmds))
+	for name := range fs.SubCmds {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// writes the schema of the default flagset to w as indented JSON
+// https://github.com/ondbyte/turbo_flag#schema
+func WriteSchema(w io.Writer) error {
+	return CommandLine.WriteSchema(w)
+}
+
+// WriteSchema writes the schema of this command and its sub commands to w as indented JSON
+// https://github.com/ondbyte/turbo_flag#schema
+func (fs *FlagSet) WriteSchema(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(fs.Schema())
+	if err != nil {
+		return fmt.Errorf("unable to write the schema of %v : %v", fs.name, err)
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package flag_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFlagSet_WriteSchema(t *testing.T) {
+	fs := newCompletionTool()
+	fs.String("token", "", "token to authenticate with", fs.Required(), fs.Env("TOOL_TOKEN"), fs.Cfg("auth.token"))
+	b := &bytes.Buffer{}
+	err := fs.WriteSchema(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden := filepath.Join("testdata", "schema", "tool.json")
+	if *update {
+		err = os.WriteFile(golden, b.Bytes(), 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.String() != string(want) {
+		t.Errorf("schema differs from %v, got\n%v", golden, b.String())
+	}
+	schema := fs.Schema()
+	if len(schema.SubCmds) != 3 || schema.SubCmds[0].Name != "commit" || !schema.SubCmds[1].Hidden {
+		t.Errorf("expected commit, the hidden completion and remote sub commands, got %+v", schema.SubCmds)
+	}
+}
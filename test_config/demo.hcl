@@ -0,0 +1,4 @@
+# demo config
+database {
+  password = "12345"
+}
@@ -0,0 +1,62 @@
+# bash completion for tool, generated by github.com/ondbyte/turbo_flag
+# load it with: source <(tool completion bash)
+
+_tool() {
+    local cur prev cmd next i
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    cmd=tool
+    next=1
+    for ((i = 1; i < COMP_CWORD; i++)); do
+        [[ ${i} -eq ${next} ]] || continue
+        case "${cmd}/${COMP_WORDS[i]}" in
+            tool/commit) cmd=tool_commit; next=$((i + 1)) ;;
+            tool/remote) cmd=tool_remote; next=$((i + 1)) ;;
+            tool_remote/add) cmd=tool_remote_add; next=$((i + 1)) ;;
+        esac
+    done
+    case "${cmd}" in
+        tool)
+            case "${prev}" in
+                -region|--region)
+                    COMPREPLY=($(compgen -W 'eu us' -- "${cur}"))
+                    return ;;
+            esac
+            if [[ "${cur}" == -* || ${COMP_CWORD} -ne ${next} ]]; then
+                COMPREPLY=($(compgen -W '--region --verbose -v' -- "${cur}"))
+            else
+                COMPREPLY=($(compgen -W 'commit remote' -- "${cur}"))
+            fi
+            ;;
+        tool_commit)
+            case "${prev}" in
+                -branch|--branch|-b|--b)
+                    COMPREPLY=($(compgen -W 'main stable' -- "${cur}"))
+                    return ;;
+                -message|--message|-m|--m)
+                    COMPREPLY=($(compgen -f -- "${cur}"))
+                    return ;;
+            esac
+            COMPREPLY=($(compgen -W '--branch -b --message -m' -- "${cur}"))
+            ;;
+        tool_remote)
+            case "${prev}" in
+            esac
+            if [[ "${cur}" == -* || ${COMP_CWORD} -ne ${next} ]]; then
+                COMPREPLY=($(compgen -W '' -- "${cur}"))
+            else
+                COMPREPLY=($(compgen -W 'add' -- "${cur}"))
+            fi
+            ;;
+        tool_remote_add)
+            case "${prev}" in
+                -name|--name)
+                    COMPREPLY=($(compgen -f -- "${cur}"))
+                    return ;;
+            esac
+            COMPREPLY=($(compgen -W '--name' -- "${cur}"))
+            ;;
+    esac
+}
+
+complete -F _tool tool
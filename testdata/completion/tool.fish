@@ -0,0 +1,41 @@
+# fish completion for tool, generated by github.com/ondbyte/turbo_flag
+# load it with: tool completion fish | source
+
+# succeeds when the command being completed is $argv[1], with a second argument
+# only when the next word can be one of its sub commands
+function __tool_is
+    set -l tokens (commandline -opc)
+    set -l cmd tool
+    set -l next 2
+    set -l i 2
+    while test $i -le (count $tokens)
+        if test $i -eq $next
+            switch "$cmd/$tokens[$i]"
+                case tool/commit
+                    set cmd tool_commit
+                    set next (math $i + 1)
+                case tool/remote
+                    set cmd tool_remote
+                    set next (math $i + 1)
+                case tool_remote/add
+                    set cmd tool_remote_add
+                    set next (math $i + 1)
+            end
+        end
+        set i (math $i + 1)
+    end
+    test "$cmd" = "$argv[1]"; or return 1
+    if set -q argv[2]
+        test $i -eq $next
+    end
+end
+
+complete -c tool -f
+complete -c tool -n '__tool_is tool sub' -a 'commit' -d 'commits the changes'
+complete -c tool -n '__tool_is tool sub' -a 'remote' -d 'manages the remotes'
+complete -c tool -n '__tool_is tool' -l region -d 'region to work with' -x -a 'eu us'
+complete -c tool -n '__tool_is tool' -l verbose -s v -d 'prints more'
+complete -c tool -n '__tool_is tool_commit' -l branch -s b -d 'branch to commit to' -x -a 'main stable'
+complete -c tool -n '__tool_is tool_commit' -l message -s m -d 'commit message' -r -F
+complete -c tool -n '__tool_is tool_remote sub' -a 'add' -d 'adds a remote'
+complete -c tool -n '__tool_is tool_remote_add' -l name -d 'name of the remote' -r -F
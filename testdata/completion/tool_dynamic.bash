@@ -0,0 +1,32 @@
+# bash completion for tool, generated by github.com/ondbyte/turbo_flag
+# load it with: source <(tool completion bash)
+
+_tool() {
+    local IFS=$'\n'
+    local cur="${COMP_WORDS[COMP_CWORD]}" directive ext
+    local -a lines
+    lines=($("${COMP_WORDS[0]}" __complete "${COMP_WORDS[@]:1:COMP_CWORD}" 2>/dev/null)) || return
+    [[ ${#lines[@]} -gt 0 ]] || return
+    directive="${lines[${#lines[@]}-1]#:}"
+    unset 'lines[${#lines[@]}-1]'
+    COMPREPLY=()
+    if (( directive & 2 )); then
+        if [[ ${#lines[@]} -eq 0 ]]; then
+            COMPREPLY=($(compgen -f -- "${cur}"))
+        else
+            for ext in "${lines[@]}"; do
+                COMPREPLY+=($(compgen -f -X "!*.${ext}" -- "${cur}"))
+            done
+            COMPREPLY+=($(compgen -d -- "${cur}"))
+        fi
+    elif (( directive & 4 )); then
+        COMPREPLY=($(compgen -d -- "${cur}"))
+    else
+        COMPREPLY=($(compgen -W "${lines[*]%%$'\t'*}" -- "${cur}"))
+        if [[ ${#COMPREPLY[@]} -eq 0 ]] && (( (directive & 1) == 0 )); then
+            COMPREPLY=($(compgen -f -- "${cur}"))
+        fi
+    fi
+}
+
+complete -F _tool tool
@@ -0,0 +1,28 @@
+# fish completion for tool, generated by github.com/ondbyte/turbo_flag
+# load it with: tool completion fish | source
+
+function __tool_complete
+    set -l tokens (commandline -opc)
+    set -l current (commandline -ct)
+    set -l lines ($tokens[1] __complete $tokens[2..-1] "$current" 2>/dev/null)
+    set -q lines[1]; or return
+    set -l directive (string replace ':' '' -- $lines[-1])
+    set -e lines[-1]
+    if test (math "floor($directive / 2) % 2") -eq 1
+        if set -q lines[1]
+            for ext in $lines
+                __fish_complete_suffix "$current" .$ext
+            end
+        else
+            __fish_complete_path "$current"
+        end
+    else if test (math "floor($directive / 4) % 2") -eq 1
+        __fish_complete_directories "$current"
+    else if set -q lines[1]
+        printf '%s\n' $lines
+    else if test (math "$directive % 2") -eq 0
+        __fish_complete_path "$current"
+    end
+end
+
+complete -c tool -f -a '(__tool_complete)'
@@ -0,0 +1,415 @@
+package flag
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// UsageData is the data the usage template of a command is rendered with
+type UsageData struct {
+	CommandPath string         // names of the commands from the root command till this one, like "git remote add"
+	Description string         // usage of the command
+	Long        bool           // true while rendering GetDefaultUsageLong, the details of the flags are expected
+	SubCmds     []UsageSubCmd  // sub commands sorted by name, hidden ones are left out
+	Plugins     []string       // plugins found for the command, see EnablePlugins
+	Flags       []UsageFlag    // flags sorted by name, aliases are listed in the flag they are defined for
+	Examples    []UsageExample // example invocations in the order they were added
+	Style       Style          // styles the parts of the usage, leaves them as they are when colors are disabled
+	catalog     Catalog        // the messages of the usage, see Msg
+
+	SubCmdGroups []UsageSubCmdGroup // sub commands in sections, the one without a title comes first
+	FlagGroups   []UsageFlagGroup   // flags in sections, the one without a title comes first
+
+	Width        int // width of the terminal the usage is wrapped to
+	SubCmdColumn int // width of the column of sub command names
+	FlagColumn   int // width of the column of flag names
+}
+
+// UsageSubCmd is a sub command in UsageData
+type UsageSubCmd struct {
+	Name  string
+	Usage string
+	Group string
+}
+
+// UsageExample is an example invocation of a command, see FlagSet.Example
+type UsageExample struct {
+	Command     string
+	Description string
+}
+
+// UsageSubCmdGroup is a section of sub commands in UsageData
+type UsageSubCmdGroup struct {
+	Title   string // empty for the sub commands not in a group
+	SubCmds []UsageSubCmd
+}
+
+// UsageFlagGroup is a section of flags in UsageData
+type UsageFlagGroup struct {
+	Title string // empty for the flags not in a group
+	Flags []UsageFlag
+}
+
+// UsageFlag is a flag in UsageData
+type UsageFlag struct {
+	Name        string
+	Names       string // the flag and its aliases with the type as shown in the usage, like "-p, --password string"
+	Type        string // name of the type of the value, empty for bool flags
+	Usage       string
+	Default     string
+	Enums       []string
+	Aliases     []string
+	Envs        []string
+	Cfgs        []string
+	Group       string
+	Required    bool
+	Deprecated  string  // why the flag is deprecated, empty if it is not
+	Replacement string  // name of the flag replacing the deprecated flag
+	catalog     Catalog // the messages of the usage of the flag, the catalog of the command
+}
+
+// DefaultUsageTemplate is the template GetDefaultUsage and GetDefaultUsageLong render UsageData with
+// unless it is replaced using SetUsageTemplate.
+const DefaultUsageTemplate = `{{if .Description}}{{wrap .Width 0 .Description}}
+
+{{end}}{{if .Flags}}{{.Style.Heading (printf "%v:" (.Msg "usage"))}}
+  {{.CommandPath}} [<flags>]
+{{end}}{{if and .Flags (or .SubCmds .Plugins)}}  {{.Msg "or"}}
+{{end}}{{if or .SubCmds .Plugins}}  {{.CommandPath}} [<sub-command>]
+{{end}}{{range .SubCmdGroups}}
+{{$.Style.Heading (printf "%v:" (or .Title ($.Msg "sub_cmds")))}}
+{{range .SubCmds}}  {{$.Style.Command (rpad .Name $.SubCmdColumn)}}  {{wrap $.Width (add $.SubCmdColumn 4) .Usage}}
+{{end}}{{end}}{{if .Plugins}}
+{{.Style.Heading (printf "%v:" (.Msg "plugins"))}}
+{{range .Plugins}}  {{$.Style.Command .}}
+{{end}}{{end}}{{range .FlagGroups}}
+{{$.Style.Heading (printf "%v:" (or .Title ($.Msg "flags")))}}
+{{range .Flags}}  {{$.Style.Flag (rpad .Names $.FlagColumn)}}  {{wrap $.Width (add $.FlagColumn 4) ($.FlagUsage .)}}
+{{end}}{{end}}{{if .Examples}}
+{{.Style.Heading (printf "%v:" (.Msg "examples"))}}
+{{range .Examples}}{{if .Description}}  # {{.Description}}
+{{end}}  {{.Command}}
+{{end}}{{end}}{{if or .SubCmds .Plugins}}
+{{.Msg "more_info" .CommandPath}}
+{{end}}`
+
+var defaultUsageTmpl = template.Must(newUsageTemplate(DefaultUsageTemplate))
+
+// usageFuncs are the functions available in the usage templates
+var usageFuncs = template.FuncMap{
+	"qjoin": qjoin,
+	"join":  strings.Join,
+	"add": func(a int, b int) int {
+		return a + b
+	},
+	// rpad pads s with spaces on the right till it is n long
+	"rpad": func(s string, n int) string {
+		return fmt.Sprintf("%-*s", n, s)
+	},
+	"wrap":      wrap,
+	"flagUsage": flagUsage,
+}
+
+// wrap wraps s to fit in width columns when it starts at the column indent,
+// the lines after the first one are indented by indent spaces.
+func wrap(width int, indent int, s string) string {
+	// too narrow to be readable, let the terminal wrap
+	if width-indent < 20 {
+		return s
+	}
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			if line != "" && visibleLen(line)+1+visibleLen(word) > width-indent {
+				lines = append(lines, line)
+				line = ""
+			}
+			if line != "" {
+				line += " "
+			}
+			line += word
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n"+strings.Repeat(" ", indent))
+}
+
+// flagUsage returns the usage of the flag followed by its default and the details of the flag when long is true
+func flagUsage(long bool, flag UsageFlag) string {
+	return styledFlagUsage(Style{}, long, flag)
+}
+
+// styledFlagUsage is flagUsage with the default styled with style, the messages are taken from the catalog
+// of the command of the flag
+func styledFlagUsage(style Style, long bool, flag UsageFlag) string {
+	catalog := flag.catalog
+	if catalog == nil {
+		catalog = English
+	}
+	usage := flag.Usage
+	if usage == "" {
+		usage = catalog.Message(MsgUsageNotAvailable)
+	}
+	var details []string
+	if flag.Deprecated != "" && flag.Replacement != "" {
+		details = append(details, catalog.Message(MsgDeprecatedUseInUsage, flag.Deprecated, flag.Replacement))
+	} else if flag.Deprecated != "" {
+		details = append(details, catalog.Message(MsgDeprecatedInUsage, flag.Deprecated))
+	}
+	if flag.Required {
+		details = append(details, catalog.Message(MsgRequired))
+	}
+	details = append(details, catalog.Message(MsgDefaultsTo, style.Default(fmt.Sprintf("%q", flag.Default))))
+	if long {
+		if len(flag.Enums) > 0 {
+			details = append(details, catalog.Message(MsgPossibleValues, qjoin(flag.Enums)))
+		}
+		if len(flag.Envs) > 0 {
+			details = append(details, catalog.Message(MsgBindsToEnvs, qjoin(flag.Envs)))
+		}
+		if len(flag.Cfgs) > 0 {
+			details = append(details, catalog.Message(MsgBindsToCfgs, qjoin(flag.Cfgs)))
+		}
+	}
+	return fmt.Sprintf("%v (%v)", usage, strings.Join(details, ", "))
+}
+
+// Msg returns the message for the key (one of the Msg constants) from the catalog of the command formatted with args
+func (d UsageData) Msg(key string, args ...interface{}) string {
+	if d.catalog == nil {
+		return English.Message(key, args...)
+	}
+	return d.catalog.Message(key, args...)
+}
+
+// FlagUsage returns the usage of the flag followed by its default and, for the long usage, its details,
+// styled with Style and taken from the catalog of the command
+func (d UsageData) FlagUsage(flag UsageFlag) string {
+	return styledFlagUsage(d.Style, d.Long, flag)
+}
+
+// qjoin quotes the strings and joins them with ", "
+func qjoin(s []string) string {
+	quoted := make([]string, len(s))
+	for i, v := range s {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// flagNames returns the names of the flag and its aliases as they are typed, single letter ones first,
+// followed by the type of the value
+func flagNames(flag *Flag) string {
+	var short, long []string
+	for _, name := range append([]string{flag.Name}, sortedKeys(flag.alias)...) {
+		if len(name) == 1 {
+			short = append(short, "-"+name)
+		} else {
+			long = append(long, "--"+name)
+		}
+	}
+	names := strings.Join(append(short, long...), ", ")
+	if typeName := valueTypeName(flag.Value); typeName != "" {
+		names += " " + typeName
+	}
+	return names
+}
+
+func newUsageTemplate(text string) (*template.Template, error) {
+	return template.New("usage").Funcs(usageFuncs).Parse(text)
+}
+
+// replaces the template the usage of the default flagset is rendered with
+// https://github.com/ondbyte/turbo_flag#usage-template
+func SetUsageTemplate(text string) error {
+	return CommandLine.SetUsageTemplate(text)
+}
+
+// replaces the template GetDefaultUsage and GetDefaultUsageLong render the usage of this command
+// and its sub commands with, the template is executed with UsageData.
+// https://github.com/ondbyte/turbo_flag#usage-template
+func (f *FlagSet) SetUsageTemplate(text string) error {
+	tmpl, err := newUsageTemplate(text)
+	if err != nil {
+		return fmt.Errorf("unable to parse the usage template : %v", err)
+	}
+	f.usageTmpl = tmpl
+	return nil
+}
+
+// sets the width the usage of the default flagset is wrapped to
+func SetUsageWidth(width int) {
+	CommandLine.SetUsageWidth(width)
+}
+
+// sets the width the usage of this command and its sub commands is wrapped to, by default
+// it is the COLUMNS env variable set by the shells, 80 if it is not set.
+func (f *FlagSet) SetUsageWidth(width int) {
+	f.usageWidth = width
+}
+
+// getUsageWidth returns the width set on this command or the closest parent, the width of the terminal otherwise
+func (f *FlagSet) getUsageWidth() int {
+	for cmd := f; cmd != nil; cmd = cmd.parentCmd {
+		if cmd.usageWidth > 0 {
+			return cmd.usageWidth
+		}
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return 80
+}
+
+// returns the usage template set on this command or the closest parent command, the default one otherwise
+func (f *FlagSet) usageTemplate() *template.Template {
+	for cmd := f; cmd != nil; cmd = cmd.parentCmd {
+		if cmd.usageTmpl != nil {
+			return cmd.usageTmpl
+		}
+	}
+	return defaultUsageTmpl
+}
+
+// UsageData returns the data the usage template of this command is rendered with,
+// long is true for the detailed usage. its Style styles only with ColorAlways, WriteUsage
+// sets the Style for the writer.
+func (f *FlagSet) UsageData(long bool) UsageData {
+	data := UsageData{
+		CommandPath: strings.Join(f.commandPath(), " "),
+		Description: f.usg,
+		Long:        long,
+		Plugins:     f.Plugins(),
+		Examples:    f.examples,
+		Style:       f.style(nil),
+		catalog:     f.catalog(),
+		Width:       f.getUsageWidth(),
+	}
+	for _, sc := range f.visibleSubCmds() {
+		data.SubCmds = append(data.SubCmds, UsageSubCmd{Name: sc.fs.name, Usage: sc.fs.usg, Group: sc.group})
+		if len(sc.fs.name) > data.SubCmdColumn {
+			data.SubCmdColumn = len(sc.fs.name)
+		}
+	}
+	hasShort := false
+	for _, flag := range sortFlags(f.formal) {
+		if flag.aliasFor != "" || flag.hidden {
+			continue
+		}
+		names := flagNames(flag)
+		hasShort = hasShort || !strings.HasPrefix(names, "--")
+		data.Flags = append(data.Flags, UsageFlag{
+			Name:        flag.Name,
+			Names:       names,
+			Type:        valueTypeName(flag.Value),
+			Usage:       f.localUsage(flag),
+			Default:     flag.DefValue,
+			Enums:       sortedKeys(flag.enums),
+			Aliases:     sortedKeys(flag.alias),
+			Envs:        sortedKeys(flag.envs),
+			Cfgs:        sortedKeys(flag.cfgs),
+			Group:       flag.group,
+			Required:    flag.required,
+			Deprecated:  flag.deprecated,
+			Replacement: flag.replacement,
+			catalog:     data.catalog,
+		})
+	}
+	for i := range data.Flags {
+		// keep the long names aligned when some of the flags have short names
+		if hasShort && strings.HasPrefix(data.Flags[i].Names, "--") {
+			data.Flags[i].Names = "    " + data.Flags[i].Names
+		}
+		if len(data.Flags[i].Names) > data.FlagColumn {
+			data.FlagColumn = len(data.Flags[i].Names)
+		}
+	}
+	for _, title := range append([]string{""}, f.subCmdGroups...) {
+		group := UsageSubCmdGroup{Title: title}
+		for _, sc := range data.SubCmds {
+			if sc.Group == title {
+				group.SubCmds = append(group.SubCmds, sc)
+			}
+		}
+		if len(group.SubCmds) > 0 {
+			data.SubCmdGroups = append(data.SubCmdGroups, group)
+		}
+	}
+	for _, title := range append([]string{""}, f.flagGroups...) {
+		group := UsageFlagGroup{Title: title}
+		for _, flag := range data.Flags {
+			if flag.Group == title {
+				group.Flags = append(group.Flags, flag)
+			}
+		}
+		if len(group.Flags) > 0 {
+			data.FlagGroups = append(data.FlagGroups, group)
+		}
+	}
+	return data
+}
+
+// lists the flag you are defining under a section titled title in the usage of the default flagset
+// https://github.com/ondbyte/turbo_flag#groups
+func Group(title string) *flagFeature {
+	return CommandLine.Group(title)
+}
+
+// lists the flag you are defining under a section titled title in the usage, the sections are listed
+// in the order they were first used, after the section of the flags not in a group.
+// https://github.com/ondbyte/turbo_flag#groups
+func (fs *FlagSet) Group(title string) *flagFeature {
+	return &flagFeature{
+		index: 12,
+		add: func(fs *FlagSet, f *Flag) {
+			f.group = title
+			fs.flagGroups = appendUnique(fs.flagGroups, title)
+		},
+	}
+}
+
+// lists the sub commands with names under a section titled title in the usage of the default flagset
+// https://github.com/ondbyte/turbo_flag#groups
+func SubCmdGroup(title string, names ...string) {
+	CommandLine.SubCmdGroup(title, names...)
+}
+
+// lists the sub commands with names under a section titled title in the usage, the sections are listed
+// in the order they were first used, after the section of the sub commands not in a group.
+// https://github.com/ondbyte/turbo_flag#groups
+func (fs *FlagSet) SubCmdGroup(title string, names ...string) {
+	for _, name := range names {
+		sc, ok := fs.SubCmds[name]
+		if !ok {
+			panic(fmt.Sprintf("you are trying to group the sub command %v but it doesn't exist", name))
+		}
+		sc.group = title
+	}
+	fs.subCmdGroups = appendUnique(fs.subCmdGroups, title)
+}
+
+func appendUnique(s []string, v string) []string {
+	for _, e := range s {
+		if e == v {
+			return s
+		}
+	}
+	return append(s, v)
+}
+
+// adds an example invocation with a description to the usage of the default flagset
+// https://github.com/ondbyte/turbo_flag#examples
+func Example(command string, description string) {
+	CommandLine.Example(command, description)
+}
+
+// adds an example invocation of this command with a description, the examples are listed
+// under "Examples:" in the usage, man page and the reference docs in the order they were added.
+// https://github.com/ondbyte/turbo_flag#examples
+func (fs *FlagSet) Example(command string, description string) {
+	fs.examples = append(fs.examples, UsageExample{Command: command, Description: description})
+}
@@ -0,0 +1,188 @@
+package flag_test
+
+import (
+	"os"
+	"testing"
+
+	. "github.com/ondbyte/turbo_flag"
+)
+
+func TestFlagSet_GetDefaultUsageLong(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetUsage("a tool")
+	fs.SetUsageWidth(200)
+	fs.String("password", "", "the password", fs.Alias("p"), fs.Env("PASS"), fs.Cfg("db.password"))
+	fs.String("mode", "a", "", fs.Enum("a", "b"))
+	usage, err := fs.GetDefaultUsageLong()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `a tool
+
+usage:
+  tool [<flags>]
+
+Flags:
+      --mode string      usage not available (defaults to "a", possible values ["a", "b"])
+  -p, --password string  the password (defaults to "", binds to env/s ["PASS"], binds to cfg/s ["db.password"])
+`
+	if usage != want {
+		t.Errorf("GetDefaultUsageLong() =\n%v\nwant\n%v", usage, want)
+	}
+}
+
+func TestFlagSet_GetDefaultUsageWraps(t *testing.T) {
+	os.Setenv("COLUMNS", "50")
+	defer os.Unsetenv("COLUMNS")
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.Bool("verbose", false, "prints the requests and the responses while talking to the server", fs.Alias("v"))
+	fs.SubCmdFs("commit", "commits the changes", func(fs *FlagSet, args []string) {})
+	fs.SubCmdFs("remote", "manages the remotes", func(fs *FlagSet, args []string) {})
+	usage, err := fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `usage:
+  tool [<flags>]
+  or
+  tool [<sub-command>]
+
+Available sub commands:
+  commit  commits the changes
+  remote  manages the remotes
+
+Flags:
+  -v, --verbose  prints the requests and the
+                 responses while talking to the
+                 server (defaults to "false")
+
+Use "tool [command] --help" for more information about a command.
+`
+	if usage != want {
+		t.Errorf("GetDefaultUsage() =\n%v\nwant\n%v", usage, want)
+	}
+}
+
+func TestFlagSet_SetUsageTemplate(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	err := fs.SetUsageTemplate(`{{.CommandPath}}:{{range .Flags}} {{.Name}}={{.Default}}{{end}}{{if .Long}}{{range .Flags}} [{{join .Aliases ","}}]{{end}}{{end}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var subUsage string
+	fs.SubCmdFs("commit", "", func(fs *FlagSet, args []string) {
+		fs.String("branch", "main", "", fs.Alias("b", "br"))
+		subUsage, err = fs.GetDefaultUsageLong()
+	})
+	err = fs.Parse([]string{"commit"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if subUsage != "tool commit: branch=main [b,br]" {
+		t.Errorf("sub command should use the template of the parent, got %q", subUsage)
+	}
+
+	err = fs.SetUsageTemplate("{{.Unknown")
+	if err == nil {
+		t.Fatal("expected error for a bad template")
+	}
+	data := fs.UsageData(false)
+	if data.CommandPath != "tool" || len(data.SubCmds) != 1 || data.SubCmds[0].Name != "commit" {
+		t.Errorf("unexpected usage data %+v", data)
+	}
+}
+
+func TestFlagSet_Group(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetUsageWidth(200)
+	fs.Bool("verbose", false, "prints more")
+	fs.Int("port", 80, "port to connect to", fs.Group("Networking"))
+	fs.Bool("trace", false, "traces the requests", fs.Group("Debugging"))
+	fs.String("host", "localhost", "host to connect to", fs.Group("Networking"))
+	fs.SubCmdFs("pull", "pulls the changes", func(fs *FlagSet, args []string) {})
+	fs.SubCmdFs("push", "pushes the changes", func(fs *FlagSet, args []string) {})
+	fs.SubCmdFs("config", "edits the config", func(fs *FlagSet, args []string) {})
+	fs.SubCmdGroup("Remote commands", "push", "pull")
+	usage, err := fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `usage:
+  tool [<flags>]
+  or
+  tool [<sub-command>]
+
+Available sub commands:
+  config  edits the config
+
+Remote commands:
+  pull    pulls the changes
+  push    pushes the changes
+
+Flags:
+  --verbose      prints more (defaults to "false")
+
+Networking:
+  --host string  host to connect to (defaults to "localhost")
+  --port int     port to connect to (defaults to "80")
+
+Debugging:
+  --trace        traces the requests (defaults to "false")
+
+Use "tool [command] --help" for more information about a command.
+`
+	if usage != want {
+		t.Errorf("GetDefaultUsage() =\n%v\nwant\n%v", usage, want)
+	}
+}
+
+func TestFlagSet_Example(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.Example("tool -v", "prints more")
+	fs.Example("tool", "")
+	fs.Bool("verbose", false, "prints more", fs.Alias("v"))
+	usage, err := fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `usage:
+  tool [<flags>]
+
+Flags:
+  -v, --verbose  prints more (defaults to "false")
+
+Examples:
+  # prints more
+  tool -v
+  tool
+`
+	if usage != want {
+		t.Errorf("GetDefaultUsage() =\n%v\nwant\n%v", usage, want)
+	}
+}
+
+func TestFlagSet_Hidden(t *testing.T) {
+	fs := NewFlagSet("tool", ContinueOnError)
+	fs.SetUsageWidth(200)
+	debug := fs.Bool("debug", false, "internal", fs.Hidden())
+	fs.String("addr", "", "address to listen on", fs.Deprecated("it is split into host and port", "host"))
+	fs.String("host", "", "host to listen on")
+	usage, err := fs.GetDefaultUsage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `usage:
+  tool [<flags>]
+
+Flags:
+  --addr string  address to listen on (deprecated, it is split into host and port, use --host instead, defaults to "")
+  --host string  host to listen on (defaults to "")
+`
+	if usage != want {
+		t.Errorf("GetDefaultUsage() =\n%v\nwant\n%v", usage, want)
+	}
+	err = fs.Parse([]string{"--debug"})
+	if err != nil || !*debug {
+		t.Fatalf("expected the hidden flag to parse : %v", err)
+	}
+}